@@ -81,3 +81,26 @@ func Truncate(in string, w int, l ...interface{}) string {
 	}
 	return result + end
 }
+
+// TruncateMiddle truncates in string to w chars keeping both ends visible, ellipsis is placed in the middle
+func TruncateMiddle(in string, w int, l ...interface{}) string {
+	end := "…"
+	if l != nil {
+		if v, ok := l[0].(string); ok {
+			end = v
+		}
+	}
+	if w < 0 {
+		w = 0
+	}
+	if runewidth.StringWidth(StripANSI(in)) <= w {
+		return in
+	}
+	runes := []rune(in)
+	if len(runes) <= w {
+		return in
+	}
+	head := (w + 1) / 2
+	tail := w - head
+	return string(runes[:head]) + end + string(runes[len(runes)-tail:])
+}
@@ -229,6 +229,11 @@ func TestTruncate(t *testing.T) {
 			args{"string", 0, nil},
 			"…",
 		},
+		{
+			"negative width",
+			args{"string", -2, nil},
+			"…",
+		},
 		{
 			"",
 			args{"string", 0, ""},
@@ -263,3 +268,54 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestTruncateMiddle(t *testing.T) {
+	type args struct {
+		in string
+		w  int
+		l  interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"short string",
+			args{"string", 9, nil},
+			"string",
+		},
+		{
+			"exact width",
+			args{"string", 6, nil},
+			"string",
+		},
+		{
+			"odd width",
+			args{"/very/long/path/to/file.go", 15, nil},
+			"/very/lo…file.go",
+		},
+		{
+			"even width",
+			args{"/very/long/path/to/file.go", 14, "..."},
+			"/very/l...file.go",
+		},
+		{
+			"zero width",
+			args{"string", 0, nil},
+			"…",
+		},
+		{
+			"empty ellipsis",
+			args{"H㐀〾▓朗퐭텟şüöžåйкл¤〾▓朗", 4, ""},
+			"H㐀▓朗",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateMiddle(tt.args.in, tt.args.w, tt.args.l); got != tt.want {
+				t.Errorf("TruncateMiddle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
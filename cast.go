@@ -0,0 +1,234 @@
+package spinner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	castVersion = 2
+	castWidth   = 80
+	castHeight  = 24
+	// CastOutput is the type of event of data written to terminal
+	CastOutput = "o"
+)
+
+// Cast represents recording of terminal session in asciinema v2 format
+type Cast struct {
+	Width  int
+	Height int
+	Title  string
+	Events []CastEvent
+}
+
+// CastEvent represents data written to terminal at Time since the recording start
+type CastEvent struct {
+	Time time.Duration
+	Type string
+	Data string
+}
+
+// castHeader is the first line of asciicast file
+type castHeader struct {
+	Version int    `json:"version"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Title   string `json:"title,omitempty"`
+}
+
+// MarshalJSON returns event as [time, type, data] array
+func (e CastEvent) MarshalJSON() ([]byte, error) {
+	typ, err := marshalNoEscape(e.Type)
+	if err != nil {
+		return nil, err
+	}
+	data, err := marshalNoEscape(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	t := strconv.FormatFloat(e.Time.Seconds(), 'f', 6, 64)
+	return []byte(fmt.Sprintf("[%s, %s, %s]", t, typ, data)), nil
+}
+
+// UnmarshalJSON parses event from [time, type, data] array
+func (e *CastEvent) UnmarshalJSON(b []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return fmt.Errorf("spinner: cast event should have 3 fields, got %v", len(fields))
+	}
+	var t float64
+	if err := json.Unmarshal(fields[0], &t); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[1], &e.Type); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[2], &e.Data); err != nil {
+		return err
+	}
+	e.Time = time.Duration(t * float64(time.Second))
+	return nil
+}
+
+// marshalNoEscape returns JSON encoding of v without escaping of HTML characters
+func marshalNoEscape(v interface{}) ([]byte, error) {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSuffix(b.String(), "\n")), nil
+}
+
+// WriteTo writes cast in asciicast v2 format: header line followed by a line per event
+func (c *Cast) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	header, err := marshalNoEscape(castHeader{Version: castVersion, Width: c.Width, Height: c.Height, Title: c.Title})
+	if err != nil {
+		return n, err
+	}
+	lines := [][]byte{header}
+	for _, e := range c.Events {
+		b, err := e.MarshalJSON()
+		if err != nil {
+			return n, err
+		}
+		lines = append(lines, b)
+	}
+	for _, line := range lines {
+		m, err := w.Write(append(line, '\n'))
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Output returns all data written to terminal during recording
+func (c *Cast) Output() string {
+	var b strings.Builder
+	for _, e := range c.Events {
+		if e.Type == CastOutput {
+			b.WriteString(e.Data)
+		}
+	}
+	return b.String()
+}
+
+// ReadCast reads cast in asciicast v2 format
+func ReadCast(r io.Reader) (*Cast, error) {
+	dec := json.NewDecoder(r)
+	var h castHeader
+	if err := dec.Decode(&h); err != nil {
+		return nil, fmt.Errorf("spinner: invalid cast header: %v", err)
+	}
+	if h.Version != castVersion {
+		return nil, fmt.Errorf("spinner: unsupported cast version: %v", h.Version)
+	}
+	c := &Cast{Width: h.Width, Height: h.Height, Title: h.Title}
+	for dec.More() {
+		var e CastEvent
+		if err := dec.Decode(&e); err != nil {
+			return nil, fmt.Errorf("spinner: invalid cast event %v: %v", len(c.Events)+1, err)
+		}
+		c.Events = append(c.Events, e)
+	}
+	return c, nil
+}
+
+// Play writes output events of cast c to w keeping intervals between events divided by speed,
+// speed 0 writes all events at once. Returns ctx error if ctx is done before playback ends
+func Play(ctx context.Context, w io.Writer, c *Cast, speed float64) error {
+	if speed < 0 {
+		return fmt.Errorf("spinner: speed should not be negative, got %v", speed)
+	}
+	start := time.Now()
+	for _, e := range c.Events {
+		if e.Type != CastOutput {
+			continue
+		}
+		if speed > 0 {
+			at := start.Add(time.Duration(float64(e.Time) / speed))
+			if d := time.Until(at); d > 0 {
+				t := time.NewTimer(d)
+				select {
+				case <-ctx.Done():
+					t.Stop()
+					return ctx.Err()
+				case <-t.C:
+				}
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, e.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recorder is a writer recording every write as output event of cast, writes are passed through
+// to underlying writer if set. Time of the first write is the start of recording
+//
+//	r := spinner.NewRecorder(os.Stderr, 80, 24)
+//	s, _ := spinner.New(spinner.Output(r))
+//	...
+//	r.Cast().WriteTo(f)
+type Recorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	cast   Cast
+	start  time.Time
+	now    func() time.Time
+	active bool // recording is started
+}
+
+// NewRecorder returns recorder passing writes to w, nil w discards them. Terminal size of cast is
+// width x height, 80x24 if not positive
+func NewRecorder(w io.Writer, width, height int) *Recorder {
+	if width <= 0 {
+		width = castWidth
+	}
+	if height <= 0 {
+		height = castHeight
+	}
+	return &Recorder{w: w, cast: Cast{Width: width, Height: height}, now: time.Now}
+}
+
+// Write records p and writes it to underlying writer
+func (r *Recorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if !r.active {
+		r.start = now
+		r.active = true
+	}
+	r.cast.Events = append(r.cast.Events, CastEvent{Time: now.Sub(r.start), Type: CastOutput, Data: string(p)})
+	if r.w == nil {
+		return len(p), nil
+	}
+	return r.w.Write(p)
+}
+
+// Cast returns copy of recording
+func (r *Recorder) Cast() *Cast {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.cast
+	c.Events = append([]CastEvent(nil), r.cast.Events...)
+	return &c
+}
@@ -0,0 +1,112 @@
+package spinner
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	var out bytes.Buffer
+	r := NewRecorder(&out, 0, 0)
+	start := time.Unix(0, 0)
+	now := start.Add(time.Hour)
+	r.now = func() time.Time { return now }
+	for _, frame := range []string{"\x1b[?25l", "- <a&b>\r", "✔ done\n"} {
+		if _, err := r.Write([]byte(frame)); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(1500 * time.Microsecond)
+	}
+	if out.String() != "\x1b[?25l- <a&b>\r✔ done\n" {
+		t.Errorf("passed through %q", out.String())
+	}
+	var b bytes.Buffer
+	if _, err := r.Cast().WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":2,"width":80,"height":24}
+[0.000000, "o", "\u001b[?25l"]
+[0.001500, "o", "- <a&b>\r"]
+[0.003000, "o", "✔ done\n"]
+`
+	if b.String() != want {
+		t.Errorf("cast:\n%s\nwant:\n%s", b.String(), want)
+	}
+	c, err := ReadCast(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Width != 80 || c.Height != 24 || len(c.Events) != 3 || c.Events[2].Time != 3*time.Millisecond {
+		t.Errorf("ReadCast() = %+v", c)
+	}
+	if c.Output() != out.String() {
+		t.Errorf("Output() = %q, want %q", c.Output(), out.String())
+	}
+}
+
+func TestReadCast(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid", "{\"version\": 2, \"width\": 10, \"height\": 2, \"timestamp\": 1}\n[0.1, \"o\", \"a\"]\n[0.2, \"i\", \"b\"]\n", false},
+		{"empty", "", true},
+		{"version", "{\"version\": 1}\n", true},
+		{"event", "{\"version\": 2}\n[0.1, \"o\"]\n", true},
+		{"time", "{\"version\": 2}\n[\"0.1\", \"o\", \"a\"]\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadCast(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadCast() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlay(t *testing.T) {
+	c := &Cast{Events: []CastEvent{
+		{Time: 0, Type: CastOutput, Data: "a"},
+		{Time: 40 * time.Millisecond, Type: "i", Data: "x"},
+		{Time: 60 * time.Millisecond, Type: CastOutput, Data: "b"},
+	}}
+	var b bytes.Buffer
+	start := time.Now()
+	if err := Play(context.Background(), &b, c, 2); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("played in %v, want at least 30ms", elapsed)
+	}
+	if b.String() != "ab" {
+		t.Errorf("played %q, want %q", b.String(), "ab")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b.Reset()
+	if err := Play(ctx, &b, c, 1); err != context.Canceled {
+		t.Errorf("Play() error = %v, want %v", err, context.Canceled)
+	}
+	if err := Play(context.Background(), &b, c, -1); err == nil {
+		t.Errorf("negative speed is accepted")
+	}
+}
+
+func TestRecordSpinner(t *testing.T) {
+	r := NewRecorder(nil, 0, 0)
+	s, err := New(Output(r), CharSet([]string{"-"}), HideCursor(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	s.Succeed("done")
+	if out := r.Cast().Output(); !strings.HasSuffix(out, "done\n") {
+		t.Errorf("recorded %q", out)
+	}
+}
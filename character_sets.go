@@ -2,9 +2,10 @@ package spinner
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
-
-	"github.com/mattn/go-runewidth"
+	"unicode/utf8"
 
 	"github.com/alecrabbit/go-cli-spinner/color"
 )
@@ -14,6 +15,13 @@ const (
 	clockOneThirty = '\U0001F55C'
 )
 
+const (
+	squareFilled = "■"
+	squareEmpty  = "□"
+	splashWidth  = 12
+	splashBar    = "███"
+)
+
 // maxCharSetSize maximum character set elements count
 const maxCharSetSize = 60
 
@@ -51,11 +59,93 @@ const (
 	Dev2
 	// Weather
 	Simple
+	// Multi-line char sets
+	Square3x3
+	Splash
+	// ASCII only char sets
+	ASCII
 )
 
 // Line is alias for Simple
 const Line = Simple
 
+// variantNames contains names of variants
+var variantNames = map[string]int{
+	"BlockVertical":   BlockVertical,
+	"BouncingBlock":   BouncingBlock,
+	"Blink":           Blink,
+	"FlyingLine":      FlyingLine,
+	"RotatingCircle":  RotatingCircle,
+	"Clock":           Clock,
+	"HalfClock":       HalfClock,
+	"HalfClock2":      HalfClock2,
+	"Snake":           Snake,
+	"Snake2":          Snake2,
+	"FlyingDots":      FlyingDots,
+	"Dots10":          Dots10,
+	"Dots13":          Dots13,
+	"Dots14":          Dots14,
+	"BlockHorizontal": BlockHorizontal,
+	"Toggle":          Toggle,
+	"Arrows01":        Arrows01,
+	"Arrows02":        Arrows02,
+	"Arrows03":        Arrows03,
+	"Arrows04":        Arrows04,
+	"Dots21":          Dots21,
+	"Dots22":          Dots22,
+	"Dots23":          Dots23,
+	"Dots24":          Dots24,
+	"Dots25":          Dots25,
+	"Dots26":          Dots26,
+	"Dev":             Dev,
+	"Dev2":            Dev2,
+	"Simple":          Simple,
+	"Square3x3":       Square3x3,
+	"Splash":          Splash,
+	"ASCII":           ASCII,
+}
+
+// VariantByName returns variant by its case-insensitive name, e.g. "Dots14"
+func VariantByName(name string) (int, bool) {
+	for n, v := range variantNames {
+		if strings.EqualFold(n, name) {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// VariantName returns name of variant v, empty string if v is unknown
+func VariantName(v int) string {
+	for n, val := range variantNames {
+		if val == v {
+			return n
+		}
+	}
+	return ""
+}
+
+// VariantChars returns copy of char set and recommended interval of variant v
+func VariantChars(v int) ([]string, time.Duration, bool) {
+	cs, ok := CharSets[v]
+	if !ok {
+		return nil, 0, false
+	}
+	return append([]string(nil), cs.chars...), cs.interval, true
+}
+
+// VariantNames returns names of variants ordered by value
+func VariantNames() []string {
+	names := make([]string, 0, len(variantNames))
+	for n := range variantNames {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return variantNames[names[i]] < variantNames[names[j]]
+	})
+	return names
+}
+
 type settings struct {
 	interval time.Duration // interval between spinner refreshes
 	chars    []string      //
@@ -65,6 +155,23 @@ type settings struct {
 // palette ...
 type palette map[int]map[color.Level]int
 
+// colorLevels contains color levels in descending order
+var colorLevels = []color.Level{color.TTrueColor, color.TColor256, color.TColor16, color.TNoColor}
+
+// colorizingSet returns colorizing set of element for color level l,
+// falls back to lower levels if set is not defined or requires higher level
+func (p palette) colorizingSet(el int, l color.Level) int {
+	for _, level := range colorLevels {
+		if level > l {
+			continue
+		}
+		if set, ok := p[el][level]; ok && color.Prototypes[set].Level <= l {
+			return set
+		}
+	}
+	return color.CNoColor
+}
+
 // defaultPalette ...
 var defaultPalette = palette{
 	Char: {
@@ -124,6 +231,11 @@ var CharSets = map[int]settings{
 		[]string{"|", "\\", "─", "/"},
 		&defaultPalette,
 	},
+	ASCII: {
+		120 * time.Millisecond,
+		[]string{"|", "/", "-", "\\"},
+		&defaultPalette,
+	},
 	Dev: { // Singe character used for dev purposes
 		400 * time.Millisecond,
 		[]string{"+"},
@@ -302,21 +414,85 @@ func fillCharSets() {
 		halfClockChars2,
 		&defaultPalette,
 	}
+	// Create multi-line sets
+	CharSets[Square3x3] = settings{
+		120 * time.Millisecond,
+		squareChars(),
+		&defaultPalette,
+	}
+	CharSets[Splash] = settings{
+		100 * time.Millisecond,
+		splashChars(),
+		&defaultPalette,
+	}
+}
+
+// squareChars returns 3x3 frames with filled square running along the perimeter
+func squareChars() []string {
+	// perimeter cells clockwise, starting from top left corner
+	perimeter := [][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 2}, {2, 2}, {2, 1}, {2, 0}, {1, 0}}
+	frames := make([]string, len(perimeter))
+	for i, p := range perimeter {
+		var rows []string
+		for row := 0; row < 3; row++ {
+			var b strings.Builder
+			for col := 0; col < 3; col++ {
+				if p[0] == row && p[1] == col {
+					b.WriteString(squareFilled)
+				} else {
+					b.WriteString(squareEmpty)
+				}
+			}
+			rows = append(rows, b.String())
+		}
+		frames[i] = strings.Join(rows, "\n")
+	}
+	return frames
+}
+
+// splashChars returns framed frames with a bar bouncing from side to side
+func splashChars() []string {
+	top := "┌" + strings.Repeat("─", splashWidth+2) + "┐"
+	bottom := "└" + strings.Repeat("─", splashWidth+2) + "┘"
+	barWidth := utf8.RuneCountInString(splashBar)
+	var positions []int
+	for i := 0; i <= splashWidth-barWidth; i++ {
+		positions = append(positions, i)
+	}
+	for i := splashWidth - barWidth - 1; i > 0; i-- {
+		positions = append(positions, i)
+	}
+	frames := make([]string, len(positions))
+	for i, p := range positions {
+		middle := "│ " +
+			strings.Repeat("░", p) +
+			splashBar +
+			strings.Repeat("░", splashWidth-barWidth-p) +
+			" │"
+		frames[i] = strings.Join([]string{top, middle, bottom}, "\n")
+	}
+	return frames
 }
 
 func checkCharSet(c []string) error {
 	if l := len(c); l > maxCharSetSize {
 		return fmt.Errorf("spinner: given charset is too big: %v, max: %v", l, maxCharSetSize)
 	}
-	var widths []int
+	var widths, heights []int
 	for _, c := range c {
-		width := runewidth.StringWidth(c)
+		width, height := frameSize(c)
 		widths = append(widths, width)
+		heights = append(heights, height)
 	}
 	for _, w := range widths {
 		if w != widths[0] {
 			return fmt.Errorf("spinner: ambiguous widths for char set:\n %v\n %v", c, widths)
 		}
 	}
+	for _, h := range heights {
+		if h != heights[0] {
+			return fmt.Errorf("spinner: ambiguous heights for char set:\n %v\n %v", c, heights)
+		}
+	}
 	return nil
 }
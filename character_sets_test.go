@@ -2,6 +2,8 @@ package spinner
 
 import (
 	"testing"
+
+	"github.com/alecrabbit/go-cli-spinner/color"
 )
 
 func Test_checkCharSet(t *testing.T) {
@@ -33,6 +35,16 @@ func Test_checkCharSet(t *testing.T) {
 			args{[]string{"0", "  ", "0"}},
 			true,
 		},
+		{
+			"multi-line char set",
+			args{[]string{"■□\n□□", "□■\n□□", "□□\n□■"}},
+			false,
+		},
+		{
+			"ambiguous heights char set",
+			args{[]string{"■□\n□□", "□■"}},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -50,3 +62,35 @@ func returnBigCharSet(size int) []string {
 	}
 	return big
 }
+
+func TestPaletteColorizingSet(t *testing.T) {
+	p := palette{
+		Message: {
+			color.TColor256: color.C256Rainbow,
+			color.TColor16:  color.CLightCyan,
+		},
+		Progress: {
+			color.TColor16: color.C256Rainbow,
+		},
+	}
+	tests := []struct {
+		name  string
+		el    int
+		level color.Level
+		want  int
+	}{
+		{"defined level", Message, color.TColor256, color.C256Rainbow},
+		{"fallback to lower level", Message, color.TTrueColor, color.C256Rainbow},
+		{"lower level", Message, color.TColor16, color.CLightCyan},
+		{"no color", Message, color.TNoColor, color.CNoColor},
+		{"set requires higher level", Progress, color.TColor16, color.CNoColor},
+		{"undefined element", Char, color.TColor256, color.CNoColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.colorizingSet(tt.el, tt.level); got != tt.want {
+				t.Errorf("colorizingSet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
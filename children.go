@@ -0,0 +1,170 @@
+package spinner
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// child holds state of child spinner
+type child struct {
+	parent  *Spinner // spinner rendering the child
+	name    string   // child name, shown after char
+	weight  float32  // weight of child progress in parent progress
+	value   float32  // progress value 0..1
+	summary string   // one-line result of finished child
+}
+
+// Child returns active child spinner rendered indented under s, see ChildWeighted()
+func (s *Spinner) Child(name string) *Spinner {
+	return s.ChildWeighted(name, 1)
+}
+
+// ChildWeighted returns active child spinner rendered indented under s. Parent progress is a weighted
+// average of children progress values, finished child collapses into a one-line result.
+// Child spinners are rendered by parent only, Start() has no effect on them. If terminal can't move
+// cursor up, children are not rendered, their results are written when parent is stopped
+func (s *Spinner) ChildWeighted(name string, weight float32) *Spinner {
+	if weight < 0 {
+		weight = 0
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	c := &Spinner{
+		l:               &sync.RWMutex{},
+		interval:        s.interval,
+		frameInterval:   s.frameInterval,
+		palette:         s.palette,
+		colorLevel:      s.colorLevel,
+		outputFormat:    s.outputFormat,
+		hideCursor:      s.hideCursor,
+		Writer:          s.Writer,
+		terminal:        s.terminal,
+		elementsOrder:   s.elementsOrder,
+		maxMessageWidth: s.maxMessageWidth,
+		messageEllipsis: s.messageEllipsis,
+		successSymbol:   s.successSymbol,
+		failureSymbol:   s.failureSymbol,
+		jsonOutput:      s.jsonOutput,
+		child: &child{
+			parent: s,
+			name:   name,
+			weight: weight,
+		},
+	}
+	charSettings := *s.charSettings
+	if s.char.height > 1 {
+		// child rows are single-line
+		charSettings.charSet = CharSets[Snake2].chars
+	}
+	messageSettings := *s.messageSettings
+	progressSettings := *s.progressSettings
+	c.charSettings, c.messageSettings, c.progressSettings = &charSettings, &messageSettings, &progressSettings
+	c.elementsSettings = map[int]*elementSettings{
+		Char:     c.charSettings,
+		Message:  c.messageSettings,
+		Progress: c.progressSettings,
+	}
+	// settings are checked by parent
+	_ = c.createElements()
+	c.active = true
+	c.startedAt = time.Now()
+	s.children = append(s.children, c)
+	return c
+}
+
+// assembleTreeFrame assembles frame of spinner with children, each child occupies its own row
+// below the spinner row, or below the block of multi-line char set. Frames are drawn from the beginning
+// of the line
+func (s *Spinner) assembleTreeFrame() {
+	// Note: external lock
+	var rows []string
+	var widths []int
+	if s.char.height > 1 {
+		rows = s.blockRows()
+		for range rows {
+			widths = append(widths, s.lineWidth())
+		}
+	} else {
+		rows = []string{s.line()}
+		widths = []int{s.lineWidth()}
+	}
+	for _, c := range s.children {
+		row := c.childRow()
+		rows = append(rows, row)
+		widths = append(widths, s.frameWidth(row))
+	}
+	s.buffer.WriteString(rowsSequence(s.terminal, rows, widths, s.rowWidths))
+	s.rowWidths = widths
+	s.currentFrameWidth = 0
+	for _, w := range widths {
+		if w > s.currentFrameWidth {
+			s.currentFrameWidth = w
+		}
+	}
+	s.currentFrameHeight = len(rows)
+}
+
+// rollup sets progress of s to weighted average of children progress values
+func (s *Spinner) rollup() {
+	// Note: external lock
+	var sum, weights float32
+	for _, c := range s.children {
+		c.l.RLock()
+		sum += c.child.value * c.child.weight
+		weights += c.child.weight
+		c.l.RUnlock()
+	}
+	if weights == 0 {
+		return
+	}
+	s.setProgress(sum / weights)
+}
+
+// childRow returns row of child spinner
+func (s *Spinner) childRow() string {
+	s.l.RLock()
+	defer s.l.RUnlock()
+	if !s.active {
+		return taskIndent + s.child.summary
+	}
+	return taskIndent + s.char.colorized() + s.child.name + " " + s.progress.colorized() + s.message.colorized()
+}
+
+// finishChild marks child spinner as finished with result r
+func (s *Spinner) finishChild(r Result, m string) {
+	s.l.Lock()
+	if !s.active {
+		s.l.Unlock()
+		return
+	}
+	s.active = false
+	symbol := s.successSymbol
+	switch r {
+	case ResultSucceeded, ResultDone:
+		s.child.value = 1
+	default:
+		symbol = s.failureSymbol
+	}
+	summary := s.child.name
+	if m != "" {
+		summary += " " + m
+	}
+	s.child.summary = fmt.Sprintf("%s %s", symbol, summary)
+	d := time.Since(s.startedAt)
+	s.l.Unlock()
+	s.emit(Event{Type: Stopped, Result: r, Duration: d, Message: m})
+}
+
+// summaries returns one-line results of children, active children are finished
+func (s *Spinner) summaries() string {
+	// Note: external lock
+	var r string
+	for _, c := range s.children {
+		c.finishChild(ResultDone, "")
+		c.l.RLock()
+		r += taskIndent + c.child.summary + "\n"
+		c.l.RUnlock()
+	}
+	return r
+}
@@ -0,0 +1,135 @@
+package spinner
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner/auxiliary"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// currentRows returns rows of freshly assembled frame without control sequences
+func currentRows(s *Spinner) []string {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.updateCurrentFrame()
+	s.assembleCurrentFrame()
+	return strings.Split(strings.Trim(auxiliary.StripANSI(string(s.currentFrame)), "\r"), "\n")
+}
+
+func TestChildren(t *testing.T) {
+	s, err := New(ColorLevel(color.TNoColor), Variant(Dev), Order(Char, Progress, Message), FinalMessage("Done\n"))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.Message("Release")
+	compile := s.Child("compile")
+	tests := s.ChildWeighted("tests", 3)
+	compile.Progress(1)
+	tests.Progress(0.2)
+	tests.Message("unit")
+	tests.Start()
+
+	rows := currentRows(s)
+	want := []string{"+ 40% Release ", "  + compile 100% ", "  + tests 20% unit "}
+	if strings.Join(rows, "|") != strings.Join(want, "|") {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+	compile.Succeed("0.5s")
+	tests.Fail("2 failed")
+	tests.Fail("again")
+	if compile.Active() || tests.Active() {
+		t.Errorf("Expected children to be inactive")
+	}
+	rows = currentRows(s)
+	want = []string{"+ 40% Release ", "  ✔ compile 0.5s", "  ✖ tests 2 failed"}
+	if strings.Join(rows, "|") != strings.Join(want, "|") {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+	if s.currentFrameHeight != 3 {
+		t.Errorf("Expected frame height 3, given: %v", s.currentFrameHeight)
+	}
+}
+
+func TestChildrenSummaries(t *testing.T) {
+	s, err := New(FinalMessage("Done\n"), Interval(20*time.Millisecond))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.Start()
+	s.Child("first").Succeed("")
+	s.Child("second").Pause()
+	time.Sleep(50 * time.Millisecond)
+	s.Stop()
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if !strings.HasSuffix(output, "Done\n  ✔ first\n  ✔ second\n\x1b[?25h") {
+		t.Errorf("Unexpected output: %q", output)
+	}
+}
+
+func TestChildrenBlockParent(t *testing.T) {
+	s, err := New(ColorLevel(color.TNoColor), Variant(Square3x3), Order(Char, Progress, Message))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	s.Writer = &syncBuffer{}
+	s.Message("Release")
+	s.Child("compile").Succeed("0.5s")
+	rows := currentRows(s)
+	if len(rows) != 4 || s.currentFrameHeight != 4 {
+		t.Errorf("Expected block rows followed by child row, given: %q", rows)
+		return
+	}
+	if !strings.Contains(rows[1], "Release") || rows[3] != "  ✔ compile 0.5s" {
+		t.Errorf("Unexpected rows: %q", rows)
+	}
+}
+
+func TestChildrenRestart(t *testing.T) {
+	s, err := New(FinalMessage("Done\n"), Interval(time.Second))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.Start()
+	s.Child("first").Succeed("")
+	s.Stop()
+	buffer.Lock()
+	buffer.Reset()
+	buffer.Unlock()
+	s.Start()
+	s.Stop()
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if strings.Contains(output, "first") {
+		t.Errorf("Expected children of previous run to be dropped, output: %q", output)
+	}
+}
+
+func TestChildConfig(t *testing.T) {
+	s, err := New(Variant(Dots14), MaxFPS(30))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	c := s.Child("compile").Config()
+	if c.MaxFPS != 30 || c.Interval != s.Config().Interval {
+		t.Errorf("Unexpected child config: %+v", c)
+	}
+	if _, err := New(c.Options()...); err != nil {
+		t.Errorf("Unexpected error of child config options (%v)", err)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+// daemon runs spinner controlled by commands read from named pipe or stdin, see serve()
+//
+//	spinner daemon -fifo /tmp/s &
+//	echo "message Deploying" > /tmp/s
+//	echo "succeed Deployed" > /tmp/s
+//	wait $! # exit code is 1 after fail
+func daemon(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	fs.SetOutput(w)
+	fifo := fs.String("fifo", "", "named pipe to read commands from, created if missing, default: stdin")
+	variant := fs.String("variant", "Snake2", "variant name or number")
+	theme := fs.String("theme", "", "theme name, overrides variant")
+	message := fs.String("message", "", "initial message")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	options, err := styleOptions(*variant, *theme)
+	if err != nil {
+		return err
+	}
+	s, err := spinner.New(append(options, spinner.HandleSignals())...)
+	if err != nil {
+		return err
+	}
+	var r io.Reader = os.Stdin
+	if *fifo != "" {
+		f, closeFIFO, err := openFIFO(*fifo)
+		if err != nil {
+			return err
+		}
+		defer closeFIFO()
+		r = f
+	}
+	s.Message(*message)
+	s.Start()
+	result, ok, err := serve(s, r)
+	if !ok {
+		s.Stop()
+	}
+	if err != nil {
+		return err
+	}
+	if result == spinner.ResultFailed {
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+// run runs command under spinner, command can control the spinner by writing commands to named pipe
+// from SPINNER_FIFO environment variable, see serve(). Exit code is 1 if command sent fail and exited
+// successfully
+//
+//	spinner run -message "Deploying" -- ./deploy.sh
+//	# deploy.sh
+//	echo "progress 0.4" > "$SPINNER_FIFO"
+func run(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(w)
+	variant := fs.String("variant", "Snake2", "variant name or number")
+	theme := fs.String("theme", "", "theme name, overrides variant")
+	message := fs.String("message", "", "message shown until command writes output, default: command line")
+	fs.Usage = func() {
+		fmt.Fprintln(w, "Usage: spinner run [flags] -- <command> [args...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("command is required")
+	}
+	options, err := styleOptions(*variant, *theme)
+	if err != nil {
+		return err
+	}
+	s, err := spinner.New(append(options, spinner.HandleSignals())...)
+	if err != nil {
+		return err
+	}
+	s.Message(*message)
+	return runCommand(s, exec.Command(fs.Arg(0), fs.Args()[1:]...), w)
+}
+
+// runCommand runs cmd under spinner s serving commands written to named pipe, warnings are written to w
+func runCommand(s *spinner.Spinner, cmd *exec.Cmd, w io.Writer) error {
+	dir, err := ioutil.TempDir("", "spinner")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fifo")
+	f, closeFIFO, err := openFIFO(path)
+	if err != nil {
+		fmt.Fprintf(w, "spinner: %v, %v is not set\n", err, fifoEnv)
+	} else {
+		defer closeFIFO()
+		cmd.Env = append(os.Environ(), fifoEnv+"="+path)
+	}
+	results := make(chan spinner.Result, 1)
+	if f != nil {
+		go func() {
+			result, _, _ := serve(s, f)
+			results <- result
+		}()
+	}
+	result := spinner.ResultDone
+	_, err = s.ExecWith(context.Background(), cmd, func() {
+		if f == nil {
+			return
+		}
+		// commands written before the command exited are served before the spinner is finished
+		_, _ = io.WriteString(f, drainCommand+"\n")
+		result = <-results
+	})
+	if result == spinner.ResultFailed && err == nil {
+		return exitError{code: 1}
+	}
+	return exitCode(err)
+}
@@ -0,0 +1,47 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+func TestRunCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     string
+		want       error
+		wantOutput string
+	}{
+		{"success", `echo "message Working" > "$SPINNER_FIFO"`, nil, "✔ sh"},
+		{"fail command", `echo "fail Broken" > "$SPINNER_FIFO"`, exitError{code: 1}, "✖ Broken"},
+		{"exit code", `echo "succeed Done" > "$SPINNER_FIFO"; exit 3`, exitError{code: 3}, "✔ Done"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// fail written right before exit is served before the spinner is finished
+			for i := 0; i < 10; i++ {
+				var b bytes.Buffer
+				s, err := spinner.New(spinner.Output(&b))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := runCommand(s, exec.Command("sh", "-c", tt.script), ioutil.Discard); err != tt.want {
+					t.Errorf("runCommand() error = %v, want %v", err, tt.want)
+				}
+				output := b.String()
+				if !strings.Contains(output, tt.wantOutput) || strings.Count(output, "✔")+strings.Count(output, "✖") != 1 {
+					t.Errorf("Unexpected output: %q, want %q", output, tt.wantOutput)
+					return
+				}
+			}
+		})
+	}
+}
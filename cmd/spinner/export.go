@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecrabbit/go-cli-spinner"
+	"github.com/alecrabbit/go-cli-spinner/render"
+)
+
+// export renders variant or all variants as animated SVG or GIF
+func export(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(w)
+	format := fs.String("format", "", "svg or gif, default: extension of -o or svg")
+	set := fs.String("color-set", "rainbow", "colorizing set of char, name or number")
+	fontSize := fs.Int("font-size", 16, "font size in pixels")
+	background := fs.String("background", "#1e1e1e", "background color")
+	foreground := fs.String("foreground", "#d0d0d0", "color of chars if color set has no color")
+	output := fs.String("o", "", "output file, default: stdout")
+	dir := fs.String("dir", "", "render all variants to directory as <variant>.<format>")
+	fs.Usage = func() {
+		fmt.Fprintln(w, "Usage: spinner export [flags] <variant>")
+		fmt.Fprintln(w, "       spinner export [flags] -dir <directory>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if (fs.NArg() == 1) == (*dir != "") {
+		fs.Usage()
+		return fmt.Errorf("either variant or -dir is required")
+	}
+	c, err := parseColorSet(*set)
+	if err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*output), ".")
+	}
+	if *format == "" {
+		*format = "svg"
+	}
+	if *format != "svg" && *format != "gif" {
+		return fmt.Errorf("unknown format: %v", *format)
+	}
+	style := render.Style{FontSize: *fontSize, Background: *background, Foreground: *foreground}
+	if *dir != "" {
+		for _, name := range spinner.VariantNames() {
+			v, _ := spinner.VariantByName(name)
+			if err := exportFile(filepath.Join(*dir, name+"."+*format), v, c, *format, style); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	v, err := parseVariant(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		return exportFile(*output, v, c, *format, style)
+	}
+	return renderVariant(os.Stdout, v, c, *format, style)
+}
+
+// exportFile renders variant v colorized by set c to file at path
+func exportFile(path string, v, c int, format string, style render.Style) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := renderVariant(f, v, c, format, style); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// renderVariant writes variant v colorized by set c to w in format
+func renderVariant(w io.Writer, v, c int, format string, style render.Style) error {
+	a, err := render.Variant(v, c)
+	if err != nil {
+		return err
+	}
+	if format == "gif" {
+		return a.GIF(w, style)
+	}
+	return a.SVG(w, style)
+}
@@ -0,0 +1,42 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// openFIFO opens named pipe at path for reading, pipe is created if path does not exist and removed by
+// returned close function. Pipe is opened for writing too so that reader does not get EOF between writers
+func openFIFO(path string) (io.ReadWriter, func(), error) {
+	created := false
+	fi, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		if err := syscall.Mkfifo(path, 0600); err != nil {
+			return nil, nil, fmt.Errorf("create fifo %v: %v", path, err)
+		}
+		created = true
+	case err != nil:
+		return nil, nil, err
+	case fi.Mode()&os.ModeNamedPipe == 0:
+		return nil, nil, fmt.Errorf("%v is not a named pipe", path)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		if created {
+			_ = os.Remove(path)
+		}
+		return nil, nil, err
+	}
+	return f, func() {
+		_ = f.Close()
+		if created {
+			_ = os.Remove(path)
+		}
+	}, nil
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// openFIFO is not supported on windows, use stdin of 'spinner daemon' instead
+func openFIFO(path string) (io.ReadWriter, func(), error) {
+	return nil, nil, fmt.Errorf("named pipes are not supported on windows: %v", path)
+}
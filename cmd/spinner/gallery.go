@@ -0,0 +1,155 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/alecrabbit/go-cli-spinner"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// galleryTick is interval between gallery redraws
+const galleryTick = 20 * time.Millisecond
+
+// cell represents animated variant of gallery
+type cell struct {
+	name     string
+	chars    []string
+	width    int
+	interval time.Duration
+	index    int       // index of current char
+	next     time.Time // time to advance to the next char
+	style    int       // index of current style
+}
+
+// galleryGrid holds gallery cells
+type galleryGrid struct {
+	cells   []*cell
+	columns int
+	width   int      // width of cell
+	styles  []string // char formats, nil if colors are disabled
+}
+
+// gallery renders all single-line variants at once until interrupted or duration is elapsed
+func gallery(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("gallery", flag.ContinueOnError)
+	fs.SetOutput(w)
+	set := fs.String("color-set", "rainbow", "colorizing set of chars, name or number")
+	level := fs.String("color", "256", "color level: none, 16, 256, truecolor")
+	columns := fs.Int("columns", 3, "number of columns")
+	duration := fs.Duration("duration", 0, "stop after duration, 0 - run until interrupted")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *columns < 1 {
+		return fmt.Errorf("number of columns should be positive")
+	}
+	c, err := parseColorSet(*set)
+	if err != nil {
+		return err
+	}
+	l, err := parseColorLevel(*level)
+	if err != nil {
+		return err
+	}
+	g := newGalleryGrid(*columns, c, l, time.Now())
+	return g.run(os.Stdout, *duration)
+}
+
+// newGalleryGrid returns grid of single-line variants colorized with set c at level l
+func newGalleryGrid(columns, c int, l color.Level, now time.Time) *galleryGrid {
+	g := &galleryGrid{columns: columns}
+	if p := color.Prototypes[c]; l != color.TNoColor && p.Level <= l {
+		g.styles = p.Handler(p.ANSIStyles)
+	}
+	for _, name := range spinner.VariantNames() {
+		v, _ := spinner.VariantByName(name)
+		chars, interval, _ := spinner.VariantChars(v)
+		width, height := spinner.CharSetSize(chars)
+		if height > 1 {
+			continue
+		}
+		g.cells = append(g.cells, &cell{name: name, chars: chars, width: width, interval: interval, next: now.Add(interval)})
+		if cw := width + 1 + len(name) + 2; cw > g.width {
+			g.width = cw
+		}
+	}
+	return g
+}
+
+// advance advances cells which interval is elapsed at now
+func (g *galleryGrid) advance(now time.Time) {
+	for _, c := range g.cells {
+		if now.Before(c.next) {
+			continue
+		}
+		c.index = (c.index + 1) % len(c.chars)
+		c.next = c.next.Add(c.interval)
+		if c.next.Before(now) {
+			c.next = now.Add(c.interval)
+		}
+		if len(g.styles) > 0 {
+			c.style = (c.style + 1) % len(g.styles)
+		}
+	}
+}
+
+// rows returns rows of grid
+func (g *galleryGrid) rows() []string {
+	var rows []string
+	var b strings.Builder
+	for i, c := range g.cells {
+		char := c.chars[c.index]
+		pad := strings.Repeat(" ", c.width-runewidth.StringWidth(char))
+		if len(g.styles) > 0 {
+			char = fmt.Sprintf(g.styles[c.style], char)
+		}
+		b.WriteString(char + pad + " " + c.name)
+		b.WriteString(strings.Repeat(" ", g.width-c.width-1-len(c.name)))
+		if (i+1)%g.columns == 0 || i == len(g.cells)-1 {
+			rows = append(rows, b.String())
+			b.Reset()
+		}
+	}
+	return rows
+}
+
+// run redraws grid to w until interrupted or duration d is elapsed, 0 - until interrupted
+func (g *galleryGrid) run(w io.Writer, d time.Duration) error {
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+	var elapsed <-chan time.Time
+	if d > 0 {
+		elapsed = time.After(d)
+	}
+	t := spinner.ANSITerminal{}
+	ticker := time.NewTicker(galleryTick)
+	defer ticker.Stop()
+	fmt.Fprint(w, t.HideCursor())
+	defer fmt.Fprint(w, t.ShowCursor())
+	for {
+		rows := g.rows()
+		if _, err := fmt.Fprint(w, "\r"+strings.Join(rows, "\n")+t.Up(len(rows)-1)+"\r"); err != nil {
+			return err
+		}
+		select {
+		case <-interrupted:
+			fmt.Fprint(w, t.Down(len(rows)-1)+"\n")
+			return nil
+		case <-elapsed:
+			fmt.Fprint(w, t.Down(len(rows)-1)+"\n")
+			return nil
+		case now := <-ticker.C:
+			g.advance(now)
+		}
+	}
+}
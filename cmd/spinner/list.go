@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+// list prints variants with interval and frame size to stdout
+func list(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(w)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return writeList(os.Stdout)
+}
+
+// writeList writes table of variants to w
+func writeList(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VARIANT\tNUMBER\tINTERVAL\tWIDTH\tHEIGHT\tFRAMES\tSAMPLE")
+	for _, name := range spinner.VariantNames() {
+		v, _ := spinner.VariantByName(name)
+		chars, interval, _ := spinner.VariantChars(v)
+		width, height := spinner.CharSetSize(chars)
+		var sample string
+		switch {
+		case height > 1:
+			sample = "(multi-line)"
+		case len(chars) > 8:
+			sample = strings.Join(chars[:8], " ") + " …"
+		default:
+			sample = strings.Join(chars, " ")
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%v\t%d\t%d\t%d\t%s\n", name, v, interval, width, height, len(chars), sample)
+	}
+	return tw.Flush()
+}
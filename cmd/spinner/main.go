@@ -0,0 +1,142 @@
+// Command spinner previews char sets and color sets and runs shell commands under a spinner
+//
+//	spinner list
+//	spinner preview [flags] <variant>
+//	spinner gallery [flags]
+//	spinner wrap [flags] -- <command> [args...]
+//	spinner run [flags] -- <command> [args...]
+//	spinner daemon [flags]
+//	spinner play [flags] <file.cast>
+//	spinner export [flags] <variant>
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecrabbit/go-cli-spinner"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+const usage = `Usage: spinner <command> [flags] [args]
+
+Commands:
+  list                               list variants with interval and frame size
+  preview [flags] <variant>          render variant live
+  gallery [flags]                    render all single-line variants at once
+  wrap [flags] -- <command> [args]   run command under a spinner
+  run [flags] -- <command> [args]    run command under a spinner controlled via $SPINNER_FIFO
+  daemon [flags]                     run spinner controlled by commands from stdin or fifo
+  play [flags] <file.cast>           replay asciicast recording of preview -record or wrap -record
+  export [flags] <variant>           render variant as animated SVG or GIF
+
+Control commands, one per line: message <text>, progress <0.4 or 40%>, pause, resume,
+succeed [text], fail [text], stop
+
+Run 'spinner <command> -h' for command flags.
+`
+
+// command runs subcommand with args, output is written to w
+type command func(args []string, w io.Writer) error
+
+var commands = map[string]command{
+	"list":    list,
+	"preview": preview,
+	"gallery": gallery,
+	"wrap":    wrap,
+	"run":     run,
+	"daemon":  daemon,
+	"play":    play,
+	"export":  export,
+}
+
+// exitError carries exit code of wrapped command
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %v", e.code)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "spinner: unknown command: %v\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err := cmd(os.Args[2:], os.Stderr); err != nil {
+		if e, ok := err.(exitError); ok {
+			os.Exit(e.code)
+		}
+		fmt.Fprintf(os.Stderr, "spinner: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// colorSetNames contains names of colorizing sets
+var colorSetNames = map[string]int{
+	"none":        color.CNoColor,
+	"default":     color.CDefault,
+	"dark":        color.CDark,
+	"lightcyan":   color.CLightCyan,
+	"blink":       color.CBlink,
+	"redbold":     color.CRedBoldItalic,
+	"rainbow":     color.C256Rainbow,
+	"yellowwhite": color.C256YellowWhite,
+	"red":         color.C256RSingle,
+}
+
+// parseColorSet returns colorizing set by name or number
+func parseColorSet(v string) (int, error) {
+	if c, ok := colorSetNames[strings.ToLower(v)]; ok {
+		return c, nil
+	}
+	c, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("unknown color set: %v, known: %v", v, strings.Join(colorSetList(), ", "))
+	}
+	if _, ok := color.Prototypes[c]; !ok {
+		return 0, fmt.Errorf("unknown color set: %v", v)
+	}
+	return c, nil
+}
+
+// colorSetList returns sorted names of colorizing sets
+func colorSetList() []string {
+	names := make([]string, 0, len(colorSetNames))
+	for n := range colorSetNames {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseColorLevel returns color level by name
+func parseColorLevel(v string) (color.Level, error) {
+	if l, ok := spinner.ColorLevelByName(v); ok {
+		return l, nil
+	}
+	return 0, fmt.Errorf("unknown color level: %v, known: none, 16, 256, truecolor", v)
+}
+
+// parseVariant returns variant by name or number
+func parseVariant(v string) (int, error) {
+	if n, ok := spinner.VariantByName(v); ok {
+		return n, nil
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		if _, _, ok := spinner.VariantChars(n); ok {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown variant: %v, run 'spinner list'", v)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+func TestWriteList(t *testing.T) {
+	var b bytes.Buffer
+	if err := writeList(&b); err != nil {
+		t.Fatal(err)
+	}
+	output := b.String()
+	for _, name := range spinner.VariantNames() {
+		if !strings.Contains(output, name) {
+			t.Errorf("variant %v is not listed", name)
+		}
+	}
+	if !strings.Contains(output, "(multi-line)") {
+		t.Errorf("multi-line variants are not marked")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		parse   func() (interface{}, error)
+		want    interface{}
+		wantErr bool
+	}{
+		{"variant name", func() (interface{}, error) { return parseVariant("dots14") }, spinner.Dots14, false},
+		{"variant number", func() (interface{}, error) { return parseVariant("9") }, spinner.Snake2, false},
+		{"unknown variant", func() (interface{}, error) { return parseVariant("dots99") }, 0, true},
+		{"color set name", func() (interface{}, error) { return parseColorSet("rainbow") }, color.C256Rainbow, false},
+		{"color set number", func() (interface{}, error) { return parseColorSet("3") }, color.CLightCyan, false},
+		{"unknown color set", func() (interface{}, error) { return parseColorSet("purple") }, 0, true},
+		{"color level", func() (interface{}, error) { return parseColorLevel("16") }, color.TColor16, false},
+		{"unknown color level", func() (interface{}, error) { return parseColorLevel("8") }, color.Level(0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGalleryGrid(t *testing.T) {
+	start := time.Now()
+	g := newGalleryGrid(4, color.CNoColor, color.TColor256, start)
+	for _, c := range g.cells {
+		if c.name == "Splash" || c.name == "Square3x3" {
+			t.Errorf("multi-line variant %v is in gallery", c.name)
+		}
+	}
+	rows := g.rows()
+	if want := (len(g.cells) + 3) / 4; len(rows) != want {
+		t.Errorf("rows = %v, want %v", len(rows), want)
+	}
+	first := g.cells[0]
+	g.advance(start.Add(first.interval))
+	if first.index != 1 {
+		t.Errorf("cell index = %v, want 1", first.index)
+	}
+}
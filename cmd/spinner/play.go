@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+// play replays asciicast recording to stdout
+func play(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("play", flag.ContinueOnError)
+	fs.SetOutput(w)
+	speed := fs.Float64("speed", 1, "playback speed, 0 - write all at once")
+	fs.Usage = func() {
+		fmt.Fprintln(w, "Usage: spinner play [flags] <file.cast>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("recording is required")
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	c, err := spinner.ReadCast(f)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+	go func() {
+		select {
+		case <-interrupted:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := spinner.Play(ctx, os.Stdout, c, *speed); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
+
+// recorder returns options recording spinner output to asciicast file at path, empty path records nothing.
+// Returned save function writes the file
+func recorder(path string) ([]spinner.Option, func() error) {
+	if path == "" {
+		return nil, func() error { return nil }
+	}
+	r := spinner.NewRecorder(os.Stderr, 0, 0)
+	return []spinner.Option{spinner.Output(r)}, func() error {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if _, err := r.Cast().WriteTo(f); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// preview renders variant live until interrupted or duration is elapsed
+func preview(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	fs.SetOutput(w)
+	set := fs.String("color-set", "rainbow", "colorizing set of char, name or number")
+	level := fs.String("color", "256", "color level: none, 16, 256, truecolor")
+	message := fs.String("message", "", "spinner message, default: variant name and interval")
+	interval := fs.Duration("interval", 0, "override recommended interval")
+	duration := fs.Duration("duration", 0, "stop after duration, 0 - run until interrupted")
+	record := fs.String("record", "", "save asciicast recording to file")
+	fs.Usage = func() {
+		fmt.Fprintln(w, "Usage: spinner preview [flags] <variant>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("variant is required")
+	}
+	v, err := parseVariant(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	c, err := parseColorSet(*set)
+	if err != nil {
+		return err
+	}
+	l, err := parseColorLevel(*level)
+	if err != nil {
+		return err
+	}
+	_, recommended, _ := spinner.VariantChars(v)
+	options := []spinner.Option{
+		spinner.Variant(v),
+		spinner.ColorLevel(l),
+		spinner.ElementPalette(spinner.Char, map[color.Level]int{
+			color.TTrueColor: c,
+			color.TColor256:  c,
+			color.TColor16:   c,
+		}),
+	}
+	if *interval > 0 {
+		options = append(options, spinner.Interval(*interval))
+		recommended = *interval
+	}
+	recording, save := recorder(*record)
+	s, err := spinner.New(append(options, recording...)...)
+	if err != nil {
+		return err
+	}
+	if *message == "" {
+		*message = fmt.Sprintf("%s %v", spinner.VariantName(v), recommended)
+	}
+	s.Message(*message)
+	wait(s, *duration)
+	return save()
+}
+
+// wait runs spinner s until interrupted or duration d is elapsed, 0 - until interrupted
+func wait(s *spinner.Spinner, d time.Duration) {
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+	var elapsed <-chan time.Time
+	if d > 0 {
+		elapsed = time.After(d)
+	}
+	s.Start()
+	select {
+	case <-interrupted:
+	case <-elapsed:
+	}
+	s.Stop()
+}
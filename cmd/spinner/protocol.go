@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+// fifoEnv is environment variable containing path of named pipe of spinner started by 'spinner run'
+const fifoEnv = "SPINNER_FIFO"
+
+// drainCommand is written by 'spinner run' after the command exits, serve() returns when it is read
+const drainCommand = "\x00drain"
+
+// serve executes commands read line by line from r on spinner s until finishing command, EOF
+// or drainCommand, malformed lines are ignored. Returns result of finishing command, ok is false
+// if r is exhausted
+//
+//	message <text>
+//	progress <0..1 or percent, e.g. 0.4 or 40%>
+//	pause
+//	resume
+//	succeed [text]
+//	fail [text]
+//	stop
+func serve(s *spinner.Spinner, r io.Reader) (result spinner.Result, ok bool, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name, arg := splitCommand(scanner.Text())
+		switch name {
+		case "message":
+			s.Message(arg)
+		case "progress":
+			if p, err := parseProgress(arg); err == nil {
+				s.Progress(p)
+			}
+		case "pause":
+			s.Pause()
+		case "resume":
+			s.Resume()
+		case "succeed":
+			s.Succeed(arg)
+			return spinner.ResultSucceeded, true, nil
+		case "fail":
+			s.Fail(arg)
+			return spinner.ResultFailed, true, nil
+		case "stop":
+			s.Stop()
+			return spinner.ResultDone, true, nil
+		case drainCommand:
+			return spinner.ResultDone, false, nil
+		}
+	}
+	return spinner.ResultDone, false, scanner.Err()
+}
+
+// splitCommand splits line into lowercased command name and argument
+func splitCommand(line string) (name, arg string) {
+	line = strings.TrimSpace(line)
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		return strings.ToLower(line[:i]), strings.TrimSpace(line[i+1:])
+	}
+	return strings.ToLower(line), ""
+}
+
+// parseProgress parses progress value 0..1 or percentage, e.g. 40%
+func parseProgress(v string) (float32, error) {
+	percent := strings.HasSuffix(v, "%")
+	p, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid progress: %v", v)
+	}
+	if percent {
+		p /= 100
+	}
+	return float32(p), nil
+}
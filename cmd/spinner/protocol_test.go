@@ -0,0 +1,85 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantEvents []string
+		wantResult spinner.Result
+		wantOK     bool
+	}{
+		{"succeed", "message Deploying\nprogress 0.4\nprogress 50%\nsucceed Deployed\nmessage ignored\n",
+			[]string{"message:Deploying", "progress", "progress", "stopped:Deployed"}, spinner.ResultSucceeded, true},
+		{"fail", "FAIL  Broken \n", []string{"stopped:Broken"}, spinner.ResultFailed, true},
+		{"pause", "pause\nresume\nstop\n", []string{"paused", "resumed", "stopped:"}, spinner.ResultDone, true},
+		{"malformed", "\nprogress x\nunknown 1\n", nil, spinner.ResultDone, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var events []string
+			s, err := spinner.New(spinner.Output(ioutil.Discard), spinner.OnEvent(func(e spinner.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch e.Type {
+				case spinner.MessageChanged, spinner.Stopped:
+					events = append(events, e.Type.String()+":"+e.Message)
+				case spinner.ProgressChanged, spinner.Paused, spinner.Resumed:
+					events = append(events, e.Type.String())
+				}
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.Start()
+			result, ok, err := serve(s, strings.NewReader(tt.input))
+			if !ok {
+				s.Stop()
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != tt.wantResult || ok != tt.wantOK {
+				t.Errorf("serve() = %v, %v, want %v, %v", result, ok, tt.wantResult, tt.wantOK)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !tt.wantOK {
+				// Stopped event of s.Stop() above
+				events = events[:len(events)-1]
+			}
+			if strings.Join(events, ",") != strings.Join(tt.wantEvents, ",") {
+				t.Errorf("events = %v, want %v", events, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		v       string
+		want    float32
+		wantErr bool
+	}{
+		{"0.4", 0.4, false},
+		{"40%", 0.4, false},
+		{"1", 1, false},
+		{"", 0, true},
+		{"forty", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseProgress(tt.v)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseProgress(%q) = %v, %v, want %v, wantErr %v", tt.v, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os/exec"
+	"syscall"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+// wrap runs command under a spinner, command output is shown on failure
+func wrap(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("wrap", flag.ContinueOnError)
+	fs.SetOutput(w)
+	variant := fs.String("variant", "Snake2", "variant name or number")
+	theme := fs.String("theme", "", "theme name, overrides variant")
+	record := fs.String("record", "", "save asciicast recording to file")
+	fs.Usage = func() {
+		fmt.Fprintln(w, "Usage: spinner wrap [flags] -- <command> [args...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("command is required")
+	}
+	options, err := styleOptions(*variant, *theme)
+	if err != nil {
+		return err
+	}
+	recording, save := recorder(*record)
+	options = append(append(options, spinner.HandleSignals()), recording...)
+	cmd := exec.Command(fs.Arg(0), fs.Args()[1:]...)
+	_, err = spinner.Exec(context.Background(), cmd, options...)
+	if err := save(); err != nil {
+		return err
+	}
+	return exitCode(err)
+}
+
+// styleOptions returns options for variant or theme
+func styleOptions(variant, theme string) ([]spinner.Option, error) {
+	if theme != "" {
+		return []spinner.Option{spinner.Theme(theme)}, nil
+	}
+	v, err := parseVariant(variant)
+	if err != nil {
+		return nil, err
+	}
+	return []spinner.Option{spinner.Variant(v)}, nil
+}
+
+// exitCode converts exit error of command to exitError, failure is already reported by spinner
+func exitCode(err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*exec.ExitError); ok {
+		if status, ok := e.Sys().(syscall.WaitStatus); ok {
+			return exitError{code: status.ExitStatus()}
+		}
+	}
+	return exitError{code: 1}
+}
@@ -0,0 +1,582 @@
+package spinner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// Config represents serializable spinner configuration, see Options(), Spinner.Config() and LoadConfig().
+// Empty fields keep defaults
+type Config struct {
+	Theme                   string                    `json:"theme,omitempty"`                     // theme name, see Themes()
+	Variant                 string                    `json:"variant,omitempty"`                   // variant name, e.g. Dots14
+	Chars                   []string                  `json:"chars,omitempty"`                     // char set, see CharSet()
+	Interval                string                    `json:"interval,omitempty"`                  // duration, e.g. 80ms
+	Reverse                 bool                      `json:"reverse,omitempty"`                   //
+	Order                   []string                  `json:"order,omitempty"`                     // element names: char, message, progress
+	CharFormat              string                    `json:"char_format,omitempty"`               // see Format()
+	MessageFormat           string                    `json:"message_format,omitempty"`            //
+	ProgressFormat          string                    `json:"progress_format,omitempty"`           //
+	ProgressIndicatorFormat string                    `json:"progress_indicator_format,omitempty"` //
+	Prefix                  string                    `json:"prefix,omitempty"`                    //
+	Ellipsis                *string                   `json:"ellipsis,omitempty"`                  //
+	MaxMessageLength        *int                      `json:"max_message_length,omitempty"`        //
+	HideCursor              *bool                     `json:"hide_cursor,omitempty"`               //
+	FinalMessage            string                    `json:"final_message,omitempty"`             //
+	SuccessSymbol           string                    `json:"success_symbol,omitempty"`            //
+	FailureSymbol           string                    `json:"failure_symbol,omitempty"`            //
+	Color                   string                    `json:"color,omitempty"`                     // color level: none, 16, 256, truecolor
+	Palette                 map[string]map[string]int `json:"palette,omitempty"`                   // element name → color level → colorizing set
+	Disable                 bool                      `json:"disable,omitempty"`                   // see Disable()
+	Truncation              string                    `json:"truncation,omitempty"`                // message truncation: end, middle
+	MarqueeStep             int                       `json:"marquee_step,omitempty"`              // see MessageMarquee(), 0 - disabled
+	MarqueePause            int                       `json:"marquee_pause,omitempty"`             //
+	Wave                    map[string]int            `json:"wave,omitempty"`                      // element name → color wave shift
+	JSONOutput              bool                      `json:"json_output,omitempty"`               // see JSONOutput()
+	HandleSignals           bool                      `json:"handle_signals,omitempty"`            // see HandleSignals()
+	MaxFPS                  int                       `json:"max_fps,omitempty"`                   // see MaxFPS()
+	MessageQueueMin         string                    `json:"message_queue_min,omitempty"`         // duration, see MessageQueue()
+	MessageQueuePolicy      int                       `json:"message_queue_policy,omitempty"`      // QueueCoalesce, QueueFlush flags
+}
+
+// colorLevelNames contains names of color levels
+var colorLevelNames = map[string]color.Level{
+	"none":      color.TNoColor,
+	"0":         color.TNoColor,
+	"16":        color.TColor16,
+	"256":       color.TColor256,
+	"truecolor": color.TTrueColor,
+}
+
+// ColorLevelByName returns color level by name: none, 16, 256, truecolor, case-insensitive
+func ColorLevelByName(name string) (color.Level, bool) {
+	l, ok := colorLevelNames[strings.ToLower(name)]
+	return l, ok
+}
+
+// colorLevelName returns name of color level l
+func colorLevelName(l color.Level) string {
+	switch l {
+	case color.TColor16:
+		return "16"
+	case color.TColor256:
+		return "256"
+	case color.TTrueColor:
+		return "truecolor"
+	}
+	return "none"
+}
+
+// truncationNames contains names of message truncation modes
+var truncationNames = map[string]int{
+	"end":    TruncateEnd,
+	"middle": TruncateMiddle,
+}
+
+// truncationName returns name of message truncation mode m
+func truncationName(m int) string {
+	if m == TruncateMiddle {
+		return "middle"
+	}
+	return "end"
+}
+
+// elementNames contains names of elements
+var elementNames = map[string]int{
+	"char":     Char,
+	"message":  Message,
+	"progress": Progress,
+}
+
+// elementName returns name of element el
+func elementName(el int) string {
+	for n, v := range elementNames {
+		if v == el {
+			return n
+		}
+	}
+	return ""
+}
+
+// Options returns options applying configuration, errors mention config field
+func (c Config) Options() []Option {
+	var options []Option
+	add := func(field string, o Option) {
+		options = append(options, fieldOption(field, o))
+	}
+	if c.Theme != "" {
+		add("theme", Theme(c.Theme))
+	}
+	if c.Variant != "" {
+		add("variant", func(s *Spinner) error {
+			v, ok := VariantByName(c.Variant)
+			if !ok {
+				return fmt.Errorf("spinner: unknown variant: %v", c.Variant)
+			}
+			return Variant(v)(s)
+		})
+	}
+	if c.Chars != nil {
+		add("chars", CharSet(c.Chars))
+	}
+	if c.Interval != "" {
+		add("interval", func(s *Spinner) error {
+			d, err := time.ParseDuration(c.Interval)
+			if err != nil {
+				return err
+			}
+			return Interval(d)(s)
+		})
+	}
+	if c.Reverse {
+		add("reverse", Reverse())
+	}
+	if c.Order != nil {
+		add("order", func(s *Spinner) error {
+			order := make([]int, len(c.Order))
+			for i, n := range c.Order {
+				el, ok := elementNames[strings.ToLower(n)]
+				if !ok {
+					return fmt.Errorf("spinner: unknown element: %v", n)
+				}
+				order[i] = el
+			}
+			return Order(order...)(s)
+		})
+	}
+	if c.CharFormat != "" {
+		add("char_format", Format(c.CharFormat))
+	}
+	if c.MessageFormat != "" {
+		add("message_format", MessageFormat(c.MessageFormat))
+	}
+	if c.ProgressFormat != "" {
+		add("progress_format", ProgressFormat(c.ProgressFormat))
+	}
+	if c.ProgressIndicatorFormat != "" {
+		add("progress_indicator_format", ProgressIndicatorFormat(c.ProgressIndicatorFormat))
+	}
+	if c.Prefix != "" {
+		add("prefix", Prefix(c.Prefix))
+	}
+	if c.Ellipsis != nil {
+		add("ellipsis", MessageEllipsis(*c.Ellipsis))
+	}
+	if c.MaxMessageLength != nil {
+		add("max_message_length", MaxMessageLength(*c.MaxMessageLength))
+	}
+	if c.HideCursor != nil {
+		add("hide_cursor", HideCursor(*c.HideCursor))
+	}
+	if c.FinalMessage != "" {
+		add("final_message", FinalMessage(c.FinalMessage))
+	}
+	if c.SuccessSymbol != "" {
+		add("success_symbol", SuccessSymbol(c.SuccessSymbol))
+	}
+	if c.FailureSymbol != "" {
+		add("failure_symbol", FailureSymbol(c.FailureSymbol))
+	}
+	if c.Color != "" {
+		add("color", func(s *Spinner) error {
+			l, ok := ColorLevelByName(c.Color)
+			if !ok {
+				return fmt.Errorf("spinner: unknown color level: %v", c.Color)
+			}
+			return ColorLevel(l)(s)
+		})
+	}
+	if c.Palette != nil {
+		add("palette", func(s *Spinner) error {
+			elements := make([]string, 0, len(c.Palette))
+			for n := range c.Palette {
+				elements = append(elements, n)
+			}
+			sort.Strings(elements)
+			for _, n := range elements {
+				el, ok := elementNames[strings.ToLower(n)]
+				if !ok {
+					return fmt.Errorf("spinner: unknown element: %v", n)
+				}
+				sets := make(map[color.Level]int, len(c.Palette[n]))
+				for ln, set := range c.Palette[n] {
+					l, ok := ColorLevelByName(ln)
+					if !ok {
+						return fmt.Errorf("spinner: unknown color level: %v", ln)
+					}
+					sets[l] = set
+				}
+				if err := ElementPalette(el, sets)(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if c.Disable {
+		add("disable", Disable())
+	}
+	if c.Truncation != "" {
+		add("truncation", func(s *Spinner) error {
+			m, ok := truncationNames[strings.ToLower(c.Truncation)]
+			if !ok {
+				return fmt.Errorf("spinner: unknown message truncation mode: %v", c.Truncation)
+			}
+			return MessageTruncation(m)(s)
+		})
+	}
+	if c.MarqueeStep != 0 || c.MarqueePause != 0 {
+		add("marquee_step", MessageMarquee(c.MarqueeStep, c.MarqueePause))
+	}
+	if c.Wave != nil {
+		add("wave", func(s *Spinner) error {
+			elements := make([]string, 0, len(c.Wave))
+			for n := range c.Wave {
+				elements = append(elements, n)
+			}
+			sort.Strings(elements)
+			for _, n := range elements {
+				el, ok := elementNames[strings.ToLower(n)]
+				if !ok {
+					return fmt.Errorf("spinner: unknown element: %v", n)
+				}
+				if err := ColorWave(el, c.Wave[n])(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if c.JSONOutput {
+		add("json_output", JSONOutput())
+	}
+	if c.HandleSignals {
+		add("handle_signals", HandleSignals())
+	}
+	if c.MaxFPS != 0 {
+		add("max_fps", MaxFPS(c.MaxFPS))
+	}
+	if c.MessageQueueMin != "" || c.MessageQueuePolicy != 0 {
+		add("message_queue_min", func(s *Spinner) error {
+			var min time.Duration
+			if c.MessageQueueMin != "" {
+				d, err := time.ParseDuration(c.MessageQueueMin)
+				if err != nil {
+					return err
+				}
+				min = d
+			}
+			return MessageQueue(min, c.MessageQueuePolicy)(s)
+		})
+	}
+	return options
+}
+
+// Config returns effective configuration of spinner, New(s.Config().Options()...) creates spinner
+// with the same settings
+func (s *Spinner) Config() Config {
+	s.l.RLock()
+	defer s.l.RUnlock()
+	ellipsis := s.messageEllipsis
+	length := s.maxMessageWidth
+	hide := s.hideCursor
+	c := Config{
+		Interval:                s.interval.String(),
+		Reverse:                 s.reversed,
+		CharFormat:              s.charSettings.format,
+		MessageFormat:           s.messageSettings.format,
+		ProgressFormat:          s.progressSettings.format,
+		ProgressIndicatorFormat: s.progressSettings.auxFormat,
+		Prefix:                  s.prefix,
+		Ellipsis:                &ellipsis,
+		MaxMessageLength:        &length,
+		HideCursor:              &hide,
+		FinalMessage:            s.finalMessage,
+		SuccessSymbol:           s.successSymbol,
+		FailureSymbol:           s.failureSymbol,
+		Color:                   colorLevelName(s.colorLevel),
+		Palette:                 map[string]map[string]int{},
+		Disable:                 s.disabled,
+		Truncation:              truncationName(s.messageTruncation),
+		MarqueeStep:             s.marqueeStep,
+		MarqueePause:            s.marqueePause,
+		JSONOutput:              s.jsonOutput,
+		HandleSignals:           s.handleSignals,
+		MaxFPS:                  int(time.Second / s.frameInterval),
+	}
+	for _, v := range VariantNames() {
+		if reflect.DeepEqual(CharSets[variantNames[v]].chars, s.charSettings.charSet) {
+			c.Variant = v
+			break
+		}
+	}
+	if c.Variant == "" {
+		c.Chars = append([]string(nil), s.charSettings.charSet...)
+	}
+	for _, el := range s.elementsOrder {
+		c.Order = append(c.Order, elementName(el))
+	}
+	for el, sets := range *s.palette {
+		p := make(map[string]int, len(sets))
+		for l, set := range sets {
+			p[colorLevelName(l)] = set
+		}
+		c.Palette[elementName(el)] = p
+	}
+	for el, settings := range s.elementsSettings {
+		if settings.wave > 0 {
+			if c.Wave == nil {
+				c.Wave = map[string]int{}
+			}
+			c.Wave[elementName(el)] = settings.wave
+		}
+	}
+	if s.queue != nil {
+		c.MessageQueueMin = s.queue.min.String()
+		c.MessageQueuePolicy = s.queue.policy
+	}
+	return c
+}
+
+// fieldOption wraps option o, error mentions config field
+func fieldOption(field string, o Option) Option {
+	return func(s *Spinner) error {
+		if err := o(s); err != nil {
+			return fmt.Errorf("spinner: config field %q: %v", field, strings.TrimPrefix(err.Error(), "spinner: "))
+		}
+		return nil
+	}
+}
+
+// configFields contains setters of config fields by name, used for flat config files and environment
+var configFields = map[string]func(c *Config, v string) error{
+	"theme":    func(c *Config, v string) error { c.Theme = v; return nil },
+	"variant":  func(c *Config, v string) error { c.Variant = v; return nil },
+	"interval": func(c *Config, v string) error { c.Interval = v; return nil },
+	"color":    func(c *Config, v string) error { c.Color = v; return nil },
+	"prefix":   func(c *Config, v string) error { c.Prefix = v; return nil },
+	"order": func(c *Config, v string) error {
+		c.Order = strings.Split(strings.Replace(v, " ", "", -1), ",")
+		return nil
+	},
+	"char_format":               func(c *Config, v string) error { c.CharFormat = v; return nil },
+	"message_format":            func(c *Config, v string) error { c.MessageFormat = v; return nil },
+	"progress_format":           func(c *Config, v string) error { c.ProgressFormat = v; return nil },
+	"progress_indicator_format": func(c *Config, v string) error { c.ProgressIndicatorFormat = v; return nil },
+	"ellipsis":                  func(c *Config, v string) error { c.Ellipsis = &v; return nil },
+	"final_message":             func(c *Config, v string) error { c.FinalMessage = v; return nil },
+	"success_symbol":            func(c *Config, v string) error { c.SuccessSymbol = v; return nil },
+	"failure_symbol":            func(c *Config, v string) error { c.FailureSymbol = v; return nil },
+	"reverse": func(c *Config, v string) error {
+		r, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean: %q", v)
+		}
+		c.Reverse = r
+		return nil
+	},
+	"max_message_length": func(c *Config, v string) error {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid integer: %q", v)
+		}
+		c.MaxMessageLength = &l
+		return nil
+	},
+	"hide_cursor": func(c *Config, v string) error {
+		h, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean: %q", v)
+		}
+		c.HideCursor = &h
+		return nil
+	},
+	"disable": func(c *Config, v string) error {
+		d, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean: %q", v)
+		}
+		c.Disable = d
+		return nil
+	},
+	"truncation": func(c *Config, v string) error { c.Truncation = v; return nil },
+	"marquee_step": func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid integer: %q", v)
+		}
+		c.MarqueeStep = n
+		return nil
+	},
+	"marquee_pause": func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid integer: %q", v)
+		}
+		c.MarqueePause = n
+		return nil
+	},
+	"json_output": func(c *Config, v string) error {
+		j, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean: %q", v)
+		}
+		c.JSONOutput = j
+		return nil
+	},
+	"handle_signals": func(c *Config, v string) error {
+		h, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean: %q", v)
+		}
+		c.HandleSignals = h
+		return nil
+	},
+	"max_fps": func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid integer: %q", v)
+		}
+		c.MaxFPS = n
+		return nil
+	},
+	"message_queue_min": func(c *Config, v string) error { c.MessageQueueMin = v; return nil },
+	"message_queue_policy": func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid integer: %q", v)
+		}
+		c.MessageQueuePolicy = n
+		return nil
+	},
+}
+
+// LoadConfig reads configuration in JSON or flat format and validates it. Flat format contains
+// "field = value" or "field: value" lines, lines starting with # are comments, order is comma-separated,
+// chars, palette and wave are supported in JSON only
+//
+//	variant = Dots14
+//	interval: 80ms
+//	color = "16"
+func LoadConfig(r io.Reader) (Config, error) {
+	var c Config
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return c, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.DisallowUnknownFields()
+		if err := d.Decode(&c); err != nil {
+			if e, ok := err.(*json.UnmarshalTypeError); ok {
+				return c, fmt.Errorf("spinner: config field %q: invalid type %v", e.Field, e.Value)
+			}
+			return c, fmt.Errorf("spinner: config: %v", err)
+		}
+	} else if err := c.parseFlat(data); err != nil {
+		return c, err
+	}
+	if _, err := New(c.Options()...); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// parseFlat parses "field = value" lines
+func (c *Config) parseFlat(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.IndexAny(line, "=:")
+		if i < 0 {
+			return fmt.Errorf("spinner: config line %v: expected field = value", n)
+		}
+		field := strings.Replace(strings.ToLower(strings.TrimSpace(line[:i])), "-", "_", -1)
+		set, ok := configFields[field]
+		if !ok {
+			return fmt.Errorf("spinner: config line %v: unknown field %q", n, field)
+		}
+		if err := set(c, unquote(strings.TrimSpace(line[i+1:]))); err != nil {
+			return fmt.Errorf("spinner: config field %q: %v", field, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// unquote removes quotes around v
+func unquote(v string) string {
+	if len(v) > 1 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		return v[1 : len(v)-1]
+	}
+	if u, err := strconv.Unquote(v); err == nil && strings.HasPrefix(v, `"`) {
+		return u
+	}
+	return v
+}
+
+// FromEnv reads configuration from environment variables, prefix is prepended with underscore
+//
+//	<PREFIX>_SPINNER=Dots14               variant or theme name
+//	<PREFIX>_SPINNER_INTERVAL=80ms
+//	<PREFIX>_SPINNER_COLOR=16             none, 16, 256, truecolor
+//	<PREFIX>_SPINNER_DISABLE=1            frames are not written
+//	<PREFIX>_SPINNER_HIDE_CURSOR=false
+//	<PREFIX>_SPINNER_MAX_MESSAGE_LENGTH=30
+//	<PREFIX>_SPINNER_PREFIX=">"
+func FromEnv(prefix string) Option {
+	return func(s *Spinner) error {
+		c, err := configFromEnv(prefix, os.LookupEnv)
+		if err != nil {
+			return err
+		}
+		for _, option := range c.Options() {
+			if err := option(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// configFromEnv returns configuration read by lookup from environment variables with prefix
+func configFromEnv(prefix string, lookup func(string) (string, bool)) (Config, error) {
+	var c Config
+	name := "SPINNER"
+	if prefix != "" {
+		name = strings.ToUpper(prefix) + "_" + name
+	}
+	if v, ok := lookup(name); ok && v != "" {
+		// variant or theme
+		if _, ok := VariantByName(v); ok {
+			c.Variant = v
+		} else {
+			c.Theme = v
+		}
+	}
+	for _, field := range []string{"interval", "color", "disable", "hide_cursor", "max_message_length", "prefix"} {
+		env := name + "_" + strings.ToUpper(field)
+		v, ok := lookup(env)
+		if !ok || v == "" {
+			continue
+		}
+		if err := configFields[field](&c, v); err != nil {
+			return c, fmt.Errorf("spinner: %v: %v", env, err)
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,286 @@
+package spinner
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+func TestLoadConfig(t *testing.T) {
+	length := 30
+	hide := false
+	tests := []struct {
+		name    string
+		input   string
+		want    Config
+		wantErr string
+	}{
+		{
+			"json",
+			`{"variant": "Dots14", "interval": "80ms", "color": "16", "max_message_length": 30, "hide_cursor": false}`,
+			Config{Variant: "Dots14", Interval: "80ms", Color: "16", MaxMessageLength: &length, HideCursor: &hide},
+			"",
+		},
+		{
+			"flat",
+			"# comment\n\nvariant = Dots14\ninterval: 80ms\ncolor = \"16\"\nmax-message-length = 30\nhide_cursor: false\n",
+			Config{Variant: "Dots14", Interval: "80ms", Color: "16", MaxMessageLength: &length, HideCursor: &hide},
+			"",
+		},
+		{"flat quoted", "prefix = '>'\ntheme = npm", Config{Prefix: ">", Theme: "npm"}, ""},
+		{"flat disable", "disable = 1", Config{Disable: true}, ""},
+		{
+			"flat order and final message",
+			"order = message, char, progress\nfinal_message = \"Done\\n\"",
+			Config{Order: []string{"message", "char", "progress"}, FinalMessage: "Done\n"},
+			"",
+		},
+		{
+			"flat output settings",
+			"truncation = middle\nmarquee_step = 2\nmarquee_pause = 5\njson_output = true\nhandle_signals = 1\nmax_fps = 30",
+			Config{Truncation: "middle", MarqueeStep: 2, MarqueePause: 5, JSONOutput: true, HandleSignals: true, MaxFPS: 30},
+			"",
+		},
+		{
+			"flat message queue",
+			"message_queue_min = 300ms\nmessage_queue_policy = 3",
+			Config{MessageQueueMin: "300ms", MessageQueuePolicy: QueueCoalesce | QueueFlush},
+			"",
+		},
+		{"json wave", `{"wave": {"message": 2}}`, Config{Wave: map[string]int{"message": 2}}, ""},
+		{"empty", "", Config{}, ""},
+		{"json unknown field", `{"speed": 1}`, Config{}, `"speed"`},
+		{"json invalid type", `{"interval": 80}`, Config{}, `"interval"`},
+		{"flat unknown field", "speed = 1", Config{}, `"speed"`},
+		{"flat no value", "variant", Config{}, "line 1"},
+		{"flat invalid boolean", "hide_cursor = maybe", Config{}, `"hide_cursor"`},
+		{"flat invalid integer", "max_message_length = long", Config{}, `"max_message_length"`},
+		{"invalid interval", "interval = fast", Config{}, `"interval"`},
+		{"interval is too small", "interval = 1ms", Config{}, `"interval"`},
+		{"unknown variant", "variant = Dots99", Config{}, `"variant"`},
+		{"unknown color", "color = 8", Config{}, `"color"`},
+		{"unknown theme", "theme = corporate", Config{}, `"theme"`},
+		{"unknown truncation", "truncation = start", Config{}, `"truncation"`},
+		{"max fps out of range", "max_fps = 1001", Config{}, `"max_fps"`},
+		{"invalid message queue min", "message_queue_min = long", Config{}, `"message_queue_min"`},
+		{"message queue policy without min", "message_queue_policy = 1", Config{}, `"message_queue_min"`},
+		{"flat invalid message queue policy", "message_queue_policy = all", Config{}, `"message_queue_policy"`},
+		{"json unknown wave element", `{"wave": {"prefix": 2}}`, Config{}, `"wave"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadConfig(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("LoadConfig() error = %v, want error mentioning %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	c, err := LoadConfig(strings.NewReader("variant = dots14\ninterval = 80ms\ncolor = none\nhide_cursor = false"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(c.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.charSettings.charSet, CharSets[Dots14].chars) {
+		t.Errorf("char set = %v, want Dots14", s.charSettings.charSet)
+	}
+	if s.interval != 80*time.Millisecond || s.colorLevel != color.TNoColor || s.hideCursor {
+		t.Errorf("interval = %v, color level = %v, hide cursor = %v", s.interval, s.colorLevel, s.hideCursor)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		env     map[string]string
+		want    Config
+		wantErr string
+	}{
+		{
+			"variant",
+			"app",
+			map[string]string{"APP_SPINNER": "Dots14", "APP_SPINNER_INTERVAL": "80ms", "APP_SPINNER_COLOR": "16"},
+			Config{Variant: "Dots14", Interval: "80ms", Color: "16"},
+			"",
+		},
+		{"theme", "app", map[string]string{"APP_SPINNER": "npm"}, Config{Theme: "npm"}, ""},
+		{"no prefix", "", map[string]string{"SPINNER_DISABLE": "1"}, Config{Disable: true}, ""},
+		{"other prefix", "app", map[string]string{"SPINNER_DISABLE": "1"}, Config{}, ""},
+		{"invalid disable", "app", map[string]string{"APP_SPINNER_DISABLE": "yes"}, Config{}, "APP_SPINNER_DISABLE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(k string) (string, bool) {
+				v, ok := tt.env[k]
+				return v, ok
+			}
+			got, err := configFromEnv(tt.prefix, lookup)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("configFromEnv() error = %v, want error mentioning %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("configFromEnv() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("configFromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	_ = os.Setenv("SPINNERTEST_SPINNER", "Dots14")
+	_ = os.Setenv("SPINNERTEST_SPINNER_DISABLE", "true")
+	defer func() {
+		_ = os.Unsetenv("SPINNERTEST_SPINNER")
+		_ = os.Unsetenv("SPINNERTEST_SPINNER_DISABLE")
+	}()
+	buffer := &syncBuffer{}
+	s, err := New(FromEnv("spinnertest"), Output(buffer), FinalMessage("Done\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.charSettings.charSet, CharSets[Dots14].chars) {
+		t.Errorf("char set = %v, want Dots14", s.charSettings.charSet)
+	}
+	s.Message("Message")
+	s.Start()
+	time.Sleep(150 * time.Millisecond)
+	s.Stop()
+	if output := buffer.String(); output != "Done\n" {
+		t.Errorf("Expected final message only, given: %v", replaceEscapes(output))
+	}
+}
+
+func TestVariantNames(t *testing.T) {
+	for v := range CharSets {
+		name := VariantName(v)
+		if name == "" {
+			t.Errorf("variant %v has no name", v)
+			continue
+		}
+		if got, ok := VariantByName(strings.ToLower(name)); !ok || got != v {
+			t.Errorf("VariantByName(%v) = %v, %v, want %v", name, got, ok, v)
+		}
+	}
+	if names := VariantNames(); len(names) != len(CharSets) || names[0] != "BlockVertical" {
+		t.Errorf("VariantNames() = %v", names)
+	}
+	if _, ok := VariantByName("Unknown"); ok {
+		t.Errorf("VariantByName() found unknown variant")
+	}
+	if l, ok := ColorLevelByName("TrueColor"); !ok || l != color.TTrueColor {
+		t.Errorf("ColorLevelByName() = %v, %v, want %v", l, ok, color.TTrueColor)
+	}
+	if _, ok := ColorLevelByName("8"); ok {
+		t.Errorf("ColorLevelByName() found unknown color level")
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{"default", nil},
+		{"theme", []Option{Theme("ascii-ci")}},
+		{"options", []Option{
+			Variant(Dots14),
+			Interval(80 * time.Millisecond),
+			Reverse(),
+			Order(Message, Char, Progress),
+			Format("-%s-"),
+			MessageFormat("(%s)"),
+			ProgressFormat("%6s"),
+			ProgressIndicatorFormat("%.1f%%"),
+			Prefix(">"),
+			MessageEllipsis("..."),
+			MaxMessageLength(20),
+			HideCursor(false),
+			FinalMessage("Done\n"),
+			SuccessSymbol("+"),
+			FailureSymbol("-"),
+			ColorLevel(color.TColor16),
+			ElementPalette(Message, map[color.Level]int{color.TColor16: color.CLightCyan}),
+			MessageTruncation(TruncateMiddle),
+			MessageMarquee(2, 5),
+			ColorWave(Message, 3),
+			JSONOutput(),
+			HandleSignals(),
+			MaxFPS(25),
+			MessageQueue(300*time.Millisecond, QueueCoalesce|QueueFlush),
+		}},
+		{"char set", []Option{CharSet([]string{"a", "b"}), Disable()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := s.Config()
+			data, err := json.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var c Config
+			if err := json.Unmarshal(data, &c); err != nil {
+				t.Fatal(err)
+			}
+			restored, err := New(c.Options()...)
+			if err != nil {
+				t.Fatalf("New() error = %v, config: %s", err, data)
+			}
+			if got := restored.Config(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Config() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestConfigOptionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		field  string
+	}{
+		{"order", Config{Order: []string{"char", "char", "message"}}, `"order"`},
+		{"order element", Config{Order: []string{"char", "title", "message"}}, `"order"`},
+		{"chars", Config{Chars: []string{"a", "bb"}}, `"chars"`},
+		{"palette element", Config{Palette: map[string]map[string]int{"title": {"16": 1}}}, `"palette"`},
+		{"palette level", Config{Palette: map[string]map[string]int{"char": {"8": 1}}}, `"palette"`},
+		{"palette set", Config{Palette: map[string]map[string]int{"char": {"16": -1}}}, `"palette"`},
+		{"prefix", Config{Prefix: "very long prefix"}, `"prefix"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config.Options()...)
+			if err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("New() error = %v, want error mentioning %v", err, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package spinner
+
+// ansiConsole represents console processing ANSI escape sequences
+type ansiConsole struct{}
+
+func newConsole() console {
+	return ansiConsole{}
+}
+
+func (ansiConsole) enableVirtualTerminal() bool {
+	return true
+}
@@ -0,0 +1,39 @@
+//go:build windows
+// +build windows
+
+package spinner
+
+import (
+	"os"
+	"syscall"
+)
+
+// enableVirtualTerminalProcessing console mode flag
+const enableVirtualTerminalProcessing = 0x0004
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// windowsConsole represents Windows console
+type windowsConsole struct {
+	handle syscall.Handle
+}
+
+func newConsole() console {
+	return windowsConsole{handle: syscall.Handle(os.Stderr.Fd())}
+}
+
+// enableVirtualTerminal enables ENABLE_VIRTUAL_TERMINAL_PROCESSING mode if available
+func (c windowsConsole) enableVirtualTerminal() bool {
+	var mode uint32
+	if err := syscall.GetConsoleMode(c.handle, &mode); err != nil {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+	r, _, _ := procSetConsoleMode.Call(uintptr(c.handle), uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
@@ -1,8 +1,10 @@
 package spinner
 
 import (
+	"bytes"
 	"container/ring"
 	"fmt"
+	"strings"
 
 	"github.com/mattn/go-runewidth"
 
@@ -15,10 +17,15 @@ type element struct {
 	spacer       string     //
 	current      string     //
 	currentWidth int        //
+	height       int        // number of rows occupied by element
 	charSet      *ring.Ring //
 	colorFormat  *ring.Ring //
 	reversed     bool       //
 	emptyFormat  string     //
+	marquee      *marquee   // scrolls current value if set
+	styles       []string   // colorizing styles, used by color wave
+	wave         int        // color wave shift between neighbouring graphemes, 0 - wave disabled
+	phase        int        // color wave phase
 }
 
 type elementSettings struct {
@@ -27,9 +34,18 @@ type elementSettings struct {
 	spacer        string
 	auxFormat     string
 	charSet       []string
+	wave          int
 }
 
+// update advances char set, marquee, color ring and color wave phase, called once per interval
 func (el *element) update() {
+	// Note: external lock
+	if el.colorFormat != nil {
+		el.colorFormat = el.colorFormat.Next()
+	}
+	if len(el.styles) > 0 {
+		el.phase = (el.phase + 1) % len(el.styles)
+	}
 	if el.charSet != nil {
 		if el.reversed {
 			el.charSet = el.charSet.Prev()
@@ -38,6 +54,17 @@ func (el *element) update() {
 		}
 		el.current = el.charSet.Value.(string)
 	}
+	if el.marquee != nil {
+		el.marquee.tick()
+		el.setCurrent(el.marquee.window())
+	}
+}
+
+func (el *element) setMarquee(m *marquee) {
+	el.marquee = m
+	if m != nil {
+		el.setCurrent(m.window())
+	}
 }
 
 func (el *element) setCurrent(s string) {
@@ -54,14 +81,18 @@ func newElement(s *elementSettings) (*element, error) {
 	el := element{
 		format: s.format, //
 		spacer: s.spacer, //
+		height: 1,        //
+		wave:   s.wave,   //
 	}
-	el.colorFormat = createColorSet(color.Prototypes[s.colorizingSet], el.format+el.spacer)
+	p := color.Prototypes[s.colorizingSet]
+	el.colorFormat = createColorSet(p, el.format+el.spacer)
+	el.styles = p.Handler(p.ANSIStyles)
 	if s.charSet != nil {
 		el.charSet = applyCharSet(s.charSet)
 		if el.charSet != nil {
-			el.currentWidth =
-				runewidth.StringWidth(el.charSet.Value.(string)) +
-					runewidth.StringWidth(fmt.Sprintf(el.format, el.spacer))
+			width, height := frameSize(el.charSet.Value.(string))
+			el.currentWidth = width + runewidth.StringWidth(fmt.Sprintf(el.format, el.spacer))
+			el.height = height
 		}
 	}
 	return &el, nil
@@ -69,15 +100,54 @@ func newElement(s *elementSettings) (*element, error) {
 
 // Colorize char
 func (el *element) colorized() string {
+	// Note: external lock
+	var b bytes.Buffer
+	el.writeColorized(&b)
+	return b.String()
+}
+
+// writeColorized writes colorized current value to b
+func (el *element) writeColorized(b *bytes.Buffer) {
+	// Note: external lock
+	switch {
+	case el.current == "":
+	case el.wave > 0 && len(el.styles) > 1:
+		b.WriteString(el.waved())
+	case el.colorFormat != nil:
+		_, _ = fmt.Fprintf(b, el.colorFormat.Value.(string), el.current)
+	default:
+		b.WriteString(el.current)
+	}
+}
+
+// waved colorizes each grapheme with its own style shifted by wave
+func (el *element) waved() string {
+	// Note: external lock
+	var b strings.Builder
+	for i, g := range graphemes(fmt.Sprintf(el.format+el.spacer, el.current)) {
+		idx := (el.phase - i*el.wave) % len(el.styles)
+		if idx < 0 {
+			idx += len(el.styles)
+		}
+		b.WriteString(fmt.Sprintf(el.styles[idx], g))
+	}
+	return b.String()
+}
+
+// colorizedRows returns colorized rows of multi-line element, each row is padded to the same width
+func (el *element) colorizedRows() []string {
 	// Note: external lock
 	if el.current == "" {
-		return ""
+		return nil
 	}
+	f := "%s"
 	if el.colorFormat != nil {
-		// rotate
-		el.colorFormat = el.colorFormat.Next()
-		// apply
-		return fmt.Sprintf(el.colorFormat.Value.(string), el.current)
+		f = el.colorFormat.Value.(string)
+	}
+	width, _ := frameSize(el.current)
+	rows := strings.Split(el.current, "\n")
+	for i, r := range rows {
+		rows[i] = fmt.Sprintf(f, r+strings.Repeat(" ", width-runewidth.StringWidth(r)))
 	}
-	return el.current
+	return rows
 }
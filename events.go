@@ -0,0 +1,101 @@
+package spinner
+
+import (
+	"time"
+)
+
+// EventType represents type of spinner event
+type EventType int
+
+// Spinner event types
+const (
+	// Started is emitted by Start()
+	Started EventType = iota
+	// MessageChanged is emitted by Message()
+	MessageChanged
+	// ProgressChanged is emitted by Progress()
+	ProgressChanged
+	// Tick is emitted on every spinner refresh
+	Tick
+	// Stopped is emitted by Stop(), Succeed() and Fail()
+	Stopped
+	// Paused is emitted by Pause()
+	Paused
+	// Resumed is emitted by Resume()
+	Resumed
+)
+
+var eventTypeNames = map[EventType]string{
+	Started:         "started",
+	MessageChanged:  "message",
+	ProgressChanged: "progress",
+	Tick:            "tick",
+	Stopped:         "stopped",
+	Paused:          "paused",
+	Resumed:         "resumed",
+}
+
+// String returns event type name
+func (t EventType) String() string {
+	if n, ok := eventTypeNames[t]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// Result represents the way spinner was stopped
+type Result int
+
+// Spinner results
+const (
+	// ResultDone spinner was stopped by Stop()
+	ResultDone Result = iota
+	// ResultSucceeded spinner was stopped by Succeed()
+	ResultSucceeded
+	// ResultFailed spinner was stopped by Fail()
+	ResultFailed
+	// ResultInterrupted spinner was stopped by signal
+	ResultInterrupted
+)
+
+var resultNames = map[Result]string{
+	ResultDone:        "done",
+	ResultSucceeded:   "succeeded",
+	ResultFailed:      "failed",
+	ResultInterrupted: "interrupted",
+}
+
+// String returns result name
+func (r Result) String() string {
+	if n, ok := resultNames[r]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// Event represents spinner lifecycle event
+type Event struct {
+	Type     EventType     // event type
+	Time     time.Time     // event timestamp
+	Message  string        // message, set for MessageChanged and Stopped, current message for ProgressChanged
+	Progress float32       // progress value 0..1, set for ProgressChanged
+	Result   Result        // result, set for Stopped
+	Duration time.Duration // time since Start(), set for Stopped
+}
+
+// EventHandler represents a function to observe spinner events
+type EventHandler func(Event)
+
+// emit passes event to all event handlers
+func (s *Spinner) emit(e Event) {
+	// Note: handlers are called without lock, so they can call spinner methods
+	if len(s.handlers) == 0 {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for _, h := range s.handlers {
+		h(e)
+	}
+}
@@ -0,0 +1,155 @@
+package spinner
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventRecorder ...
+type eventRecorder struct {
+	sync.Mutex
+	events []Event
+}
+
+// handle ...
+func (r *eventRecorder) handle(e Event) {
+	r.Lock()
+	defer r.Unlock()
+	r.events = append(r.events, e)
+}
+
+// types returns recorded event types except ticks
+func (r *eventRecorder) types() []EventType {
+	r.Lock()
+	defer r.Unlock()
+	var t []EventType
+	for _, e := range r.events {
+		if e.Type != Tick {
+			t = append(t, e.Type)
+		}
+	}
+	return t
+}
+
+// last returns last recorded event
+func (r *eventRecorder) last() Event {
+	r.Lock()
+	defer r.Unlock()
+	return r.events[len(r.events)-1]
+}
+
+func TestEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		stop   func(s *Spinner)
+		result Result
+		output string
+	}{
+		{
+			"stop",
+			func(s *Spinner) { s.Stop() },
+			ResultDone,
+			"",
+		},
+		{
+			"succeed",
+			func(s *Spinner) { s.Succeed("Done") },
+			ResultSucceeded,
+			"✔ Done\n",
+		},
+		{
+			"fail",
+			func(s *Spinner) { s.Fail("Error") },
+			ResultFailed,
+			"✖ Error\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &eventRecorder{}
+			s, err := New(Interval(time.Second), OnEvent(r.handle))
+			if err != nil {
+				t.Errorf("Unexpected error (%v)", err)
+				return
+			}
+			buffer := &syncBuffer{}
+			s.Writer = buffer
+			s.Start()
+			s.Message("Message")
+			s.Progress(0.5)
+			tt.stop(s)
+			tt.stop(s)
+			want := []EventType{Started, MessageChanged, ProgressChanged, Stopped}
+			if got := r.types(); !reflect.DeepEqual(got, want) {
+				t.Errorf("events = %v, want %v", got, want)
+			}
+			e := r.last()
+			if e.Result != tt.result {
+				t.Errorf("result = %v, want %v", e.Result, tt.result)
+			}
+			if e.Time.IsZero() || e.Duration <= 0 {
+				t.Errorf("Expected time and duration to be set, given: %v, %v", e.Time, e.Duration)
+			}
+			buffer.Lock()
+			output := buffer.String()
+			buffer.Unlock()
+			if !strings.Contains(output, tt.output) {
+				t.Errorf("Expected output to contain %q, given: %q", tt.output, output)
+			}
+		})
+	}
+}
+
+func TestEventTypeString(t *testing.T) {
+	tests := []struct {
+		t    EventType
+		want string
+	}{
+		{Started, "started"},
+		{MessageChanged, "message"},
+		{ProgressChanged, "progress"},
+		{Tick, "tick"},
+		{Stopped, "stopped"},
+		{EventType(13), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.t.String(); got != tt.want {
+			t.Errorf("EventType(%d).String() = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestHandlerCallsLifecycleMethods(t *testing.T) {
+	var s *Spinner
+	r := &eventRecorder{}
+	s, err := New(Output(&syncBuffer{}), OnEvent(r.handle), OnEvent(func(e Event) {
+		switch e.Type {
+		case Started:
+			s.Pause()
+		case Paused:
+			s.Resume()
+		case Resumed:
+			s.Stop()
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		s.Start()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler calling spinner methods deadlocks")
+	}
+	want := []EventType{Started, Paused, Resumed, Stopped}
+	if got := r.types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+func main() {
+	s, err := spinner.New(
+		// Multi-line char set, frames are drawn from the beginning of the line
+		spinner.Variant(spinner.Splash),
+		spinner.FinalMessage("\x1b[38;5;34mInitialized!\x1b[0m\n"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Installer")
+	// Start spinner
+	s.Start()
+	for i := 0; i <= 10; i++ {
+		s.Message(fmt.Sprintf("Initializing step %v", i))
+		s.Progress(float32(i) / float32(10))
+		// Doing some work
+		time.Sleep(500 * time.Millisecond)
+	}
+	// Stop spinner
+	s.Stop()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner"
+)
+
+func main() {
+	tasks, err := spinner.NewTasks(
+		spinner.Variant(spinner.Dots14),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tasks.Add("Checking environment", work(500*time.Millisecond))
+	tasks.Add("Building", func(t *spinner.Task) error {
+		// Subtasks are run after task body
+		t.Concurrency = 2
+		for _, target := range []string{"linux", "darwin", "windows"} {
+			t.Add(target, work(time.Second))
+		}
+		return nil
+	})
+	tasks.Add("Running tests", func(t *spinner.Task) error {
+		for i := 1; i <= 5; i++ {
+			t.Message(fmt.Sprintf("suite %v of 5", i))
+			time.Sleep(300 * time.Millisecond)
+		}
+		return nil
+	})
+	tasks.Add("Publishing docs", func(t *spinner.Task) error {
+		return t.Skip("docs are up to date")
+	})
+	tasks.Add("Releasing", work(time.Second))
+	if err := tasks.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func work(d time.Duration) spinner.TaskFunc {
+	return func(t *spinner.Task) error {
+		time.Sleep(d)
+		return nil
+	}
+}
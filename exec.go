@@ -0,0 +1,134 @@
+package spinner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/alecrabbit/go-cli-spinner/auxiliary"
+)
+
+// Exec runs cmd under a new spinner created with options, see Spinner.Exec()
+func Exec(ctx context.Context, cmd *exec.Cmd, options ...Option) ([]byte, error) {
+	s, err := New(options...)
+	if err != nil {
+		return nil, err
+	}
+	return s.Exec(ctx, cmd)
+}
+
+// Exec starts the spinner and runs cmd under it, full output is buffered and returned.
+// Command line is shown as message unless message is already set, then the last line of output is shown.
+// On success the spinner collapses to a single line. On failure output is written after the failure message.
+// Command is killed if ctx is done before it exits
+func (s *Spinner) Exec(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	return s.ExecWith(ctx, cmd, nil)
+}
+
+// ExecWith runs cmd as Exec() does and calls exited after the command exits, before the spinner is finished.
+// Exited can finish the spinner itself, e.g. after handling pending updates
+func (s *Spinner) ExecWith(ctx context.Context, cmd *exec.Cmd, exited func()) ([]byte, error) {
+	c := &outputCapture{spinner: s}
+	cmd.Stdout = teeWriter(cmd.Stdout, c)
+	cmd.Stderr = teeWriter(cmd.Stderr, c)
+	name := strings.Join(cmd.Args, " ")
+
+	s.l.RLock()
+	empty := s.message.current == ""
+	s.l.RUnlock()
+	if empty {
+		s.Message(name)
+	}
+	s.Start()
+	err := run(ctx, cmd)
+	output := c.bytes()
+	if exited != nil {
+		exited()
+	}
+	if err != nil {
+		s.Fail(fmt.Sprintf("%s: %v", name, err))
+		s.l.Lock()
+		if !s.jsonOutput {
+			s.write(string(output))
+		}
+		s.l.Unlock()
+		return output, err
+	}
+	s.Succeed(name)
+	return output, nil
+}
+
+// run runs cmd, kills it if ctx is done
+func run(ctx context.Context, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	exited := make(chan struct{})
+	defer close(exited)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = cmd.Process.Kill()
+		case <-exited:
+		}
+	}()
+	err := cmd.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// teeWriter returns c or writer duplicating writes to w and c if w is set
+func teeWriter(w io.Writer, c io.Writer) io.Writer {
+	if w == nil {
+		return c
+	}
+	return io.MultiWriter(w, c)
+}
+
+// outputCapture buffers command output and shows its last line as spinner message
+type outputCapture struct {
+	sync.Mutex
+	spinner *Spinner
+	buffer  bytes.Buffer
+	partial string // incomplete line
+}
+
+// Write ...
+func (c *outputCapture) Write(p []byte) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+	c.buffer.Write(p)
+	lines := strings.Split(c.partial+string(p), "\n")
+	c.partial = lines[len(lines)-1]
+	// the last non-empty line, incomplete line counts too
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := sanitizeLine(lines[i]); line != "" {
+			c.spinner.Message(line)
+			break
+		}
+	}
+	return len(p), nil
+}
+
+// bytes returns buffered output
+func (c *outputCapture) bytes() []byte {
+	c.Lock()
+	defer c.Unlock()
+	return append([]byte(nil), c.buffer.Bytes()...)
+}
+
+// sanitizeLine strips ansi codes and keeps text after the last carriage return
+func sanitizeLine(line string) string {
+	line = auxiliary.StripANSI(line)
+	line = strings.TrimRight(line, "\r")
+	if i := strings.LastIndex(line, "\r"); i >= 0 {
+		line = line[i+1:]
+	}
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,121 @@
+package spinner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// helperCommand returns command running TestHelperProcess with given scenario
+func helperCommand(scenario string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess", "--", scenario)
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1")
+	return cmd
+}
+
+// TestHelperProcess isn't a real test, it's used as a child process by Exec tests
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	switch os.Args[len(os.Args)-1] {
+	case "success":
+		fmt.Println("Compiling")
+		fmt.Fprintln(os.Stderr, "\x1b[33mwarning\x1b[0m")
+		fmt.Print("Linking\r50%\r100%\n")
+		os.Exit(0)
+	case "failure":
+		fmt.Println("Compiling")
+		fmt.Fprintln(os.Stderr, "error: undefined")
+		os.Exit(3)
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	}
+	os.Exit(0)
+}
+
+func TestExec(t *testing.T) {
+	tests := []struct {
+		name       string
+		scenario   string
+		timeout    time.Duration
+		wantErr    bool
+		wantOutput []string
+		wantFinal  string
+	}{
+		{
+			"success",
+			"success",
+			10 * time.Second,
+			false,
+			[]string{"Compiling\n", "warning", "100%\n"},
+			"✔ ",
+		},
+		{
+			"failure",
+			"failure",
+			10 * time.Second,
+			true,
+			[]string{"Compiling\n", "error: undefined\n"},
+			"✖ ",
+		},
+		{
+			"context timeout",
+			"sleep",
+			100 * time.Millisecond,
+			true,
+			nil,
+			"context deadline exceeded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			buffer := &syncBuffer{}
+			output, err := Exec(ctx, helperCommand(tt.scenario), Output(buffer), Interval(20*time.Millisecond))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Exec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, w := range tt.wantOutput {
+				if !strings.Contains(string(output), w) {
+					t.Errorf("Expected output to contain %q, given: %q", w, output)
+				}
+			}
+			buffer.Lock()
+			written := buffer.String()
+			buffer.Unlock()
+			if !strings.Contains(written, tt.wantFinal) {
+				t.Errorf("Expected %q to be written, given: %q", tt.wantFinal, written)
+			}
+			if tt.wantErr && !strings.HasSuffix(written, string(output)) {
+				t.Errorf("Expected output to be dumped on failure, given: %q", written)
+			}
+		})
+	}
+}
+
+func TestSanitizeLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"plain", "Compiling", "Compiling"},
+		{"ansi", "\x1b[33mwarning\x1b[0m", "warning"},
+		{"carriage returns", "Linking\r50%\r100%", "100%"},
+		{"crlf", "Done\r", "Done"},
+		{"spaces", "  indented  ", "indented"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeLine(tt.line); got != tt.want {
+				t.Errorf("sanitizeLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"container/ring"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
 
 	"github.com/alecrabbit/go-cli-spinner/color"
 )
@@ -24,6 +27,96 @@ func eraseSequence(w int) string {
 	return fmt.Sprintf("\x1b[%vX", w)
 }
 
+// moveUpSequence returns string containing ANSI move cursor up sequence
+func moveUpSequence(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("\x1b[%vA", n)
+}
+
+// moveDownSequence returns string containing ANSI move cursor down sequence
+func moveDownSequence(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("\x1b[%vB", n)
+}
+
+// eraseBlockSequence returns string containing terminal t sequence to erase h rows of w width,
+// cursor returns to the first row
+func eraseBlockSequence(t Terminal, w, h int) string {
+	if h <= 1 {
+		return t.ClearLine(w)
+	}
+	var b strings.Builder
+	for i := 0; i < h; i++ {
+		if i > 0 {
+			b.WriteString(t.Down(1))
+		}
+		b.WriteString(t.ClearLine(w))
+	}
+	b.WriteString(t.Up(h - 1))
+	return b.String()
+}
+
+// rowsSequence returns rows joined by new lines, each row is followed by sequence erasing the rest of
+// previous row, cursor returns to the beginning of the first row
+func rowsSequence(t Terminal, rows []string, widths, previous []int) string {
+	var b strings.Builder
+	b.WriteString("\r")
+	for i, r := range rows {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(r)
+		if i < len(previous) {
+			b.WriteString(t.ClearLine(previous[i] - widths[i]))
+		}
+	}
+	b.WriteString(t.Up(len(rows) - 1))
+	b.WriteString("\r")
+	return b.String()
+}
+
+// CharSetSize returns width and height of the largest frame of char set chars
+func CharSetSize(chars []string) (w, h int) {
+	for _, c := range chars {
+		fw, fh := frameSize(c)
+		if fw > w {
+			w = fw
+		}
+		if fh > h {
+			h = fh
+		}
+	}
+	return w, h
+}
+
+// frameSize returns width and height of multi-line frame f
+func frameSize(f string) (w, h int) {
+	rows := strings.Split(f, "\n")
+	for _, r := range rows {
+		if rw := runewidth.StringWidth(r); rw > w {
+			w = rw
+		}
+	}
+	return w, len(rows)
+}
+
+// graphemes splits s into graphemes, zero width runes are joined with preceding rune
+func graphemes(s string) []string {
+	var r []string
+	for _, c := range s {
+		if runewidth.RuneWidth(c) == 0 && len(r) > 0 {
+			r[len(r)-1] += string(c)
+			continue
+		}
+		r = append(r, string(c))
+	}
+	return r
+}
+
 // replace all "\x1b" to `\e`
 func replaceEscapes(in string) string {
 	return strings.ReplaceAll(in, "\x1b", `\e`)
@@ -49,3 +142,24 @@ func applyCharSet(charSet []string) (r *ring.Ring) {
 	}
 	return
 }
+
+// throttle returns interval between redraws after frame was written in d. Slow writes make interval
+// grow up to maxInterval, interval decays gradually back to min
+func throttle(current, min, d time.Duration) time.Duration {
+	t := 2 * d
+	if t < min {
+		t = min
+	}
+	if t > maxInterval {
+		t = maxInterval
+	}
+	if t >= current {
+		return t
+	}
+	// decay
+	current -= current / 4
+	if current < t {
+		return t
+	}
+	return current
+}
@@ -1,7 +1,9 @@
 package spinner
 
 import (
+	"reflect"
 	"testing"
+	"time"
 )
 
 var moveBackSequences = map[int]string{
@@ -40,6 +42,185 @@ func TestEraseSequence(t *testing.T) {
 	}
 }
 
+var moveUpSequences = map[int]string{
+	0:  "",
+	-1: "",
+	1:  "\x1b[1A",
+	2:  "\x1b[2A",
+}
+
+// TestMoveUpSequence ...
+func TestMoveUpSequence(t *testing.T) {
+	for n, r := range moveUpSequences {
+		sequence := moveUpSequence(n)
+		if sequence != r {
+			t.Errorf("moveUpSequence(%v) returned incorrect value", n)
+		}
+	}
+}
+
+var moveDownSequences = map[int]string{
+	0:  "",
+	-1: "",
+	1:  "\x1b[1B",
+	2:  "\x1b[2B",
+}
+
+// TestMoveDownSequence ...
+func TestMoveDownSequence(t *testing.T) {
+	for n, r := range moveDownSequences {
+		sequence := moveDownSequence(n)
+		if sequence != r {
+			t.Errorf("moveDownSequence(%v) returned incorrect value", n)
+		}
+	}
+}
+
+func TestEraseBlockSequence(t *testing.T) {
+	type args struct {
+		w int
+		h int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"single row",
+			args{3, 1},
+			"\x1b[3X",
+		},
+		{
+			"zero rows",
+			args{3, 0},
+			"\x1b[3X",
+		},
+		{
+			"three rows",
+			args{4, 3},
+			"\x1b[4X\x1b[1B\x1b[4X\x1b[1B\x1b[4X\x1b[2A",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eraseBlockSequence(ANSITerminal{}, tt.args.w, tt.args.h); got != tt.want {
+				t.Errorf("eraseBlockSequence() = %v, want %v", replaceEscapes(got), replaceEscapes(tt.want))
+			}
+		})
+	}
+}
+
+func TestRowsSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []string
+		widths   []int
+		previous []int
+		want     string
+	}{
+		{
+			"single row",
+			[]string{"ab"},
+			[]int{2},
+			nil,
+			"\rab\r",
+		},
+		{
+			"shorter rows",
+			[]string{"ab", "c", "def"},
+			[]int{2, 1, 3},
+			[]int{4, 1},
+			"\rab\x1b[2X\nc\ndef\x1b[2A\r",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rowsSequence(ANSITerminal{}, tt.rows, tt.widths, tt.previous); got != tt.want {
+				t.Errorf("rowsSequence() = %v, want %v", replaceEscapes(got), replaceEscapes(tt.want))
+			}
+		})
+	}
+}
+
+func TestFrameSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame string
+		wantW int
+		wantH int
+	}{
+		{
+			"empty frame",
+			"",
+			0,
+			1,
+		},
+		{
+			"single row",
+			"⠏",
+			1,
+			1,
+		},
+		{
+			"rows of different width",
+			"■□\n■□□\n■",
+			3,
+			3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := frameSize(tt.frame)
+			if w != tt.wantW || h != tt.wantH {
+				t.Errorf("frameSize() = %v, %v, want %v, %v", w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestCharSetSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		chars []string
+		wantW int
+		wantH int
+	}{
+		{"empty", nil, 0, 0},
+		{"single row", []string{"⠏", "⠛"}, 1, 1},
+		{"the largest frame", []string{"■", "■□□\n■", "■□"}, 3, 2},
+		{"wide chars", []string{"漢", "a"}, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := CharSetSize(tt.chars)
+			if w != tt.wantW || h != tt.wantH {
+				t.Errorf("CharSetSize() = %v, %v, want %v, %v", w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"empty string", "", nil},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"wide runes", "H㐀〾", []string{"H", "㐀", "〾"}},
+		{"combining marks", "e\u0301a\u0308", []string{"e\u0301", "a\u0308"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graphemes(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("graphemes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 type testedString struct {
 	expected string
 	given    string
@@ -78,3 +259,28 @@ func TestReplaceEscapes(t *testing.T) {
 // 		}
 // 	}
 // }
+
+func TestThrottle(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name    string
+		current time.Duration
+		d       time.Duration
+		want    time.Duration
+	}{
+		{"fast writer", 0, ms, 16 * ms},
+		{"fast writer keeps min", 16 * ms, ms, 16 * ms},
+		{"slow writer", 16 * ms, 50 * ms, 100 * ms},
+		{"very slow writer", 16 * ms, 10 * time.Second, maxInterval},
+		{"decays", 100 * ms, ms, 75 * ms},
+		{"decays to min", 20 * ms, ms, 16 * ms},
+		{"decays to writer pace", 100 * ms, 45 * ms, 90 * ms},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := throttle(tt.current, 16*ms, tt.d); got != tt.want {
+				t.Errorf("throttle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package spinner
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner/auxiliary"
+)
+
+const (
+	// OutputEnv is the name of environment variable to select spinner output mode
+	OutputEnv = "SPINNER_OUTPUT"
+	// OutputJSON is the value of OutputEnv to select JSON output mode
+	OutputJSON = "json"
+)
+
+// jsonEvent represents event in machine-readable output
+type jsonEvent struct {
+	Time     time.Time `json:"ts"`
+	Event    string    `json:"event"`
+	Message  string    `json:"message,omitempty"`
+	Value    *float32  `json:"value,omitempty"`
+	Result   string    `json:"result,omitempty"`
+	Duration float64   `json:"duration,omitempty"`
+}
+
+// MarshalJSON returns JSON encoding of event
+func (e Event) MarshalJSON() ([]byte, error) {
+	r := jsonEvent{
+		Time:  e.Time,
+		Event: e.Type.String(),
+	}
+	switch e.Type {
+	case MessageChanged:
+		r.Message = plainMessage(e.Message)
+	case ProgressChanged:
+		r.Message = plainMessage(e.Message)
+		v := e.Progress
+		r.Value = &v
+	case Stopped:
+		r.Message = plainMessage(e.Message)
+		r.Result = e.Result.String()
+		r.Duration = e.Duration.Seconds()
+	}
+	return json.Marshal(r)
+}
+
+// plainMessage strips ansi codes and surrounding spaces from message
+func plainMessage(m string) string {
+	return strings.TrimSpace(auxiliary.StripANSI(m))
+}
+
+// writeJSON writes event as a line of JSON to output
+func (s *Spinner) writeJSON(e Event) {
+	if e.Type == Tick {
+		return
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.write(string(b) + "\n")
+}
+
+// writeJSONPrompt writes question of Prompt() as a line of JSON to output
+func (s *Spinner) writeJSONPrompt(question string) {
+	// Note: external lock
+	b, err := json.Marshal(jsonEvent{Time: time.Now(), Event: "prompt", Message: strings.TrimSpace(question)})
+	if err != nil {
+		return
+	}
+	s.write(string(b) + "\n")
+}
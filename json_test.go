@@ -0,0 +1,126 @@
+package spinner
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		env     string
+	}{
+		{
+			"option",
+			[]Option{JSONOutput()},
+			"",
+		},
+		{
+			"environment variable",
+			nil,
+			OutputJSON,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Setenv(OutputEnv, tt.env)
+			defer func() { _ = os.Unsetenv(OutputEnv) }()
+			s, err := New(append(tt.options, Interval(time.Second))...)
+			if err != nil {
+				t.Errorf("Unexpected error (%v)", err)
+				return
+			}
+			buffer := &syncBuffer{}
+			s.Writer = buffer
+			s.Start()
+			s.Message("Message")
+			s.Progress(0.42)
+			s.Erase()
+			s.Current()
+			s.Succeed("\x1b[32mDone\x1b[0m")
+			buffer.Lock()
+			output := buffer.String()
+			buffer.Unlock()
+			if strings.Contains(output, "\x1b") {
+				t.Errorf("Unexpected escape sequences in output: %q", output)
+			}
+			if !strings.Contains(output, `"event":"progress","message":"Message"`) {
+				t.Errorf("Progress event without message: %q", output)
+			}
+			var events []string
+			var last map[string]interface{}
+			for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+				last = map[string]interface{}{}
+				if err := json.Unmarshal([]byte(line), &last); err != nil {
+					t.Errorf("Unexpected error (%v) on line %q", err, line)
+					return
+				}
+				events = append(events, last["event"].(string))
+			}
+			want := []string{"started", "message", "progress", "stopped"}
+			if !reflect.DeepEqual(events, want) {
+				t.Errorf("events = %v, want %v", events, want)
+			}
+			if last["result"] != "succeeded" || last["message"] != "Done" {
+				t.Errorf("Unexpected stopped event: %v", last)
+			}
+		})
+	}
+}
+
+func TestEventMarshalJSON(t *testing.T) {
+	ts := time.Date(2019, 10, 20, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{
+			"started",
+			Event{Type: Started, Time: ts},
+			`{"ts":"2019-10-20T12:00:00Z","event":"started"}`,
+		},
+		{
+			"message",
+			Event{Type: MessageChanged, Time: ts, Message: "Message"},
+			`{"ts":"2019-10-20T12:00:00Z","event":"message","message":"Message"}`,
+		},
+		{
+			"colored message",
+			Event{Type: MessageChanged, Time: ts, Message: "\x1b[33mMessage\x1b[0m "},
+			`{"ts":"2019-10-20T12:00:00Z","event":"message","message":"Message"}`,
+		},
+		{
+			"progress",
+			Event{Type: ProgressChanged, Time: ts, Message: "\x1b[33mMessage\x1b[0m", Progress: 0.5},
+			`{"ts":"2019-10-20T12:00:00Z","event":"progress","message":"Message","value":0.5}`,
+		},
+		{
+			"zero progress",
+			Event{Type: ProgressChanged, Time: ts},
+			`{"ts":"2019-10-20T12:00:00Z","event":"progress","value":0}`,
+		},
+		{
+			"stopped",
+			Event{Type: Stopped, Time: ts, Result: ResultFailed, Duration: 1500 * time.Millisecond},
+			`{"ts":"2019-10-20T12:00:00Z","event":"stopped","result":"failed","duration":1.5}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.event)
+			if err != nil {
+				t.Errorf("Unexpected error (%v)", err)
+				return
+			}
+			if string(b) != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
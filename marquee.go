@@ -0,0 +1,82 @@
+package spinner
+
+import (
+	"github.com/mattn/go-runewidth"
+)
+
+// marquee scrolls long text within a window of fixed width
+type marquee struct {
+	text      []rune // text to scroll
+	width     int    // window width in cells
+	last      int    // the last offset, text after it fits in window, 0 if the whole text fits
+	step      int    // ticks per one cell shift
+	pause     int    // ticks to pause at each end
+	offset    int    // current window offset in runes
+	ticks     int    // ticks passed since last shift
+	wait      int    // ticks left to pause
+	direction int    // scroll direction 1 or -1
+}
+
+func newMarquee(text string, width, step, pause int) *marquee {
+	if step < 1 {
+		step = 1
+	}
+	if pause < 0 {
+		pause = 0
+	}
+	m := &marquee{
+		text:      []rune(text),
+		width:     width,
+		step:      step,
+		pause:     pause,
+		wait:      pause,
+		direction: 1,
+	}
+	w := 0
+	for i := len(m.text) - 1; i >= 0; i-- {
+		w += runewidth.RuneWidth(m.text[i])
+		if w > width {
+			m.last = i + 1
+			break
+		}
+	}
+	return m
+}
+
+// tick advances marquee state by one tick
+func (m *marquee) tick() {
+	if m.wait > 0 {
+		m.wait--
+		return
+	}
+	m.ticks++
+	if m.ticks < m.step {
+		return
+	}
+	m.ticks = 0
+	m.offset += m.direction
+	if m.offset <= 0 || m.offset >= m.last {
+		if m.offset < 0 {
+			m.offset = 0
+		}
+		if m.offset > m.last {
+			m.offset = m.last
+		}
+		m.direction = -m.direction
+		m.wait = m.pause
+	}
+}
+
+// window returns visible part of text, at most width cells wide
+func (m *marquee) window() string {
+	w := 0
+	end := m.offset
+	for end < len(m.text) {
+		w += runewidth.RuneWidth(m.text[end])
+		if w > m.width {
+			break
+		}
+		end++
+	}
+	return string(m.text[m.offset:end])
+}
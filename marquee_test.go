@@ -0,0 +1,112 @@
+package spinner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarquee(t *testing.T) {
+	type args struct {
+		text  string
+		width int
+		step  int
+		pause int
+		ticks int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			"short text",
+			args{"abc", 5, 1, 0, 3},
+			[]string{"abc", "abc", "abc"},
+		},
+		{
+			"no pause",
+			args{"abcde", 3, 1, 0, 6},
+			[]string{"bcd", "cde", "bcd", "abc", "bcd", "cde"},
+		},
+		{
+			"two ticks per cell",
+			args{"abcd", 3, 2, 0, 4},
+			[]string{"abc", "bcd", "bcd", "abc"},
+		},
+		{
+			"wide chars",
+			args{"漢字かなabc", 5, 1, 0, 5},
+			[]string{"字か", "かなa", "なabc", "かなa", "字か"},
+		},
+		{
+			"wide chars fit by runes",
+			args{"漢字かな", 6, 1, 0, 2},
+			[]string{"字かな", "漢字か"},
+		},
+		{
+			"pause at each end",
+			args{"abcd", 3, 1, 2, 7},
+			[]string{"abc", "abc", "bcd", "bcd", "bcd", "abc", "abc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMarquee(tt.args.text, tt.args.width, tt.args.step, tt.args.pause)
+			var got []string
+			for i := 0; i < tt.args.ticks; i++ {
+				m.tick()
+				got = append(got, m.window())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("marquee windows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		message string
+		want    string
+	}{
+		{
+			"truncate end",
+			[]Option{MaxMessageLength(10)},
+			"/very/long/path/to/file.go",
+			"/very/long…",
+		},
+		{
+			"truncate middle",
+			[]Option{MaxMessageLength(10), MessageTruncation(TruncateMiddle)},
+			"/very/long/path/to/file.go",
+			"/very…le.go",
+		},
+		{
+			"marquee",
+			[]Option{MaxMessageLength(10), MessageMarquee(1, 0)},
+			"/very/long/path/to/file.go",
+			"/very/long",
+		},
+		{
+			"marquee short message",
+			[]Option{MaxMessageLength(10), MessageMarquee(1, 0)},
+			"file.go",
+			"file.go",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.options...)
+			if err != nil {
+				t.Errorf("Unexpected error (%v)", err)
+				return
+			}
+			s.Message(tt.message)
+			if s.message.current != tt.want {
+				t.Errorf("Message() current = %v, want %v", s.message.current, tt.want)
+			}
+		})
+	}
+}
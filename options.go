@@ -2,6 +2,7 @@ package spinner
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"time"
 
@@ -18,6 +19,14 @@ const (
 	Progress
 )
 
+// Message truncation modes
+const (
+	// TruncateEnd cuts the end of long message
+	TruncateEnd = iota
+	// TruncateMiddle cuts the middle of long message keeping both ends visible
+	TruncateMiddle
+)
+
 const (
 	// maxPrefixWidth spinner's max prefix width
 	maxPrefixWidth = 10
@@ -25,6 +34,12 @@ const (
 	minInterval = 20 * time.Millisecond
 	// maxInterval
 	maxInterval = 5 * time.Second
+	// defaultMaxFPS default max number of redraws per second
+	defaultMaxFPS = 60
+	// maxFPS
+	maxFPS = 1000
+	// defaultOutputFormat elements are written without formatting
+	defaultOutputFormat = "%s%s%s%s"
 )
 
 // Option type for functional options
@@ -177,6 +192,14 @@ func FinalMessage(m string) Option {
 	}
 }
 
+// Disable disables animation, frames are not written, final messages are
+func Disable() Option {
+	return func(s *Spinner) error {
+		s.disabled = true
+		return nil
+	}
+}
+
 // HideCursor sets spinner's hideCursor flag
 func HideCursor(h bool) Option {
 	return func(s *Spinner) error {
@@ -207,3 +230,171 @@ func MessageEllipsis(l string) Option {
 		return nil
 	}
 }
+
+// MessageTruncation sets spinner's message truncation mode - TruncateEnd, TruncateMiddle
+func MessageTruncation(m int) Option {
+	return func(s *Spinner) error {
+		if m != TruncateEnd && m != TruncateMiddle {
+			return fmt.Errorf("spinner: unknown message truncation mode: %v", m)
+		}
+		s.messageTruncation = m
+		return nil
+	}
+}
+
+// MessageQueue makes messages to be shown for at least min duration each, messages set by Message()
+// are queued meanwhile. Policy is a combination of QueueCoalesce and QueueFlush, 0 - every
+// message is shown, pending messages are discarded by Stop(). At most 100 messages are pending,
+// the oldest ones are dropped if messages come faster, so QueueFlush waits at most 100*min
+func MessageQueue(min time.Duration, policy int) Option {
+	return func(s *Spinner) error {
+		if min <= 0 {
+			return fmt.Errorf("spinner: min message display time should be positive, given: %v", min)
+		}
+		if policy&^(QueueCoalesce|QueueFlush) != 0 {
+			return fmt.Errorf("spinner: unknown message queue policy: %v", policy)
+		}
+		s.queue = &messageQueue{min: min, policy: policy, next: s.showQueued}
+		return nil
+	}
+}
+
+// MessageMarquee enables scrolling of messages longer than max message length,
+// message shifts one cell per step ticks and pauses for pause ticks at each end
+func MessageMarquee(step, pause int) Option {
+	return func(s *Spinner) error {
+		if step < 1 {
+			return fmt.Errorf("spinner: marquee step should be positive, given: %v", step)
+		}
+		if pause < 0 {
+			return fmt.Errorf("spinner: marquee pause should not be negative, given: %v", pause)
+		}
+		s.marqueeStep = step
+		s.marqueePause = pause
+		return nil
+	}
+}
+
+// ElementPalette sets colorizing sets of element for each color level
+func ElementPalette(el int, p map[color.Level]int) Option {
+	return func(s *Spinner) error {
+		if _, ok := s.elementsSettings[el]; !ok {
+			return fmt.Errorf("spinner: unknown element: %v", el)
+		}
+		sets := make(map[color.Level]int, len(p))
+		for l, c := range p {
+			if _, ok := color.SupportedLevels[l]; !ok {
+				return fmt.Errorf("spinner: unknown color level: %v", l)
+			}
+			if _, ok := color.Prototypes[c]; !ok {
+				return fmt.Errorf("spinner: unknown colorizing set: %v", c)
+			}
+			sets[l] = c
+		}
+		// copy to keep shared palettes untouched
+		np := make(palette, len(*s.palette))
+		for k, v := range *s.palette {
+			np[k] = v
+		}
+		np[el] = sets
+		s.palette = &np
+		return nil
+	}
+}
+
+// ColorWave colorizes each grapheme of element with shifted style, e.g. rainbow shimmer over message,
+// shift is a number of style steps between neighbouring graphemes
+func ColorWave(el int, shift int) Option {
+	return func(s *Spinner) error {
+		settings, ok := s.elementsSettings[el]
+		if !ok {
+			return fmt.Errorf("spinner: unknown element: %v", el)
+		}
+		if shift < 1 {
+			return fmt.Errorf("spinner: color wave shift should be positive, given: %v", shift)
+		}
+		settings.wave = shift
+		return nil
+	}
+}
+
+// OnEvent adds spinner event handler, handlers are called synchronously
+// by the goroutine causing the event. Tick handlers should not stop the spinner:
+// Stop() waits for the render goroutine which is calling the handler
+func OnEvent(h EventHandler) Option {
+	return func(s *Spinner) error {
+		if h == nil {
+			return fmt.Errorf("spinner: event handler is nil")
+		}
+		s.handlers = append(s.handlers, h)
+		return nil
+	}
+}
+
+// SuccessSymbol sets symbol printed by Succeed()
+func SuccessSymbol(sym string) Option {
+	return func(s *Spinner) error {
+		s.successSymbol = sym
+		return nil
+	}
+}
+
+// FailureSymbol sets symbol printed by Fail()
+func FailureSymbol(sym string) Option {
+	return func(s *Spinner) error {
+		s.failureSymbol = sym
+		return nil
+	}
+}
+
+// JSONOutput makes spinner write events as lines of JSON instead of frames,
+// same as setting environment variable SPINNER_OUTPUT=json
+func JSONOutput() Option {
+	return func(s *Spinner) error {
+		s.jsonOutput = true
+		return nil
+	}
+}
+
+// HandleSignals makes spinner handle SIGINT and SIGTERM while active, see RestoreOnExit()
+func HandleSignals() Option {
+	return func(s *Spinner) error {
+		s.handleSignals = true
+		return nil
+	}
+}
+
+// Output sets spinner's Writer
+func Output(w io.Writer) Option {
+	return func(s *Spinner) error {
+		if w == nil {
+			return fmt.Errorf("spinner: output writer is nil")
+		}
+		s.Writer = w
+		return nil
+	}
+}
+
+// TerminalControl sets strategy of cursor control sequences, see ANSITerminal, CarriageReturnTerminal
+// and NoOpTerminal. By default CarriageReturnTerminal is used if TERM=dumb, ANSITerminal otherwise
+func TerminalControl(t Terminal) Option {
+	return func(s *Spinner) error {
+		if t == nil {
+			return fmt.Errorf("spinner: terminal is nil")
+		}
+		s.terminal = t
+		return nil
+	}
+}
+
+// MaxFPS sets max number of redraws per second, default: 60. Message and progress changes are redrawn
+// promptly but not more often, redraws are throttled further if writing frames is slow
+func MaxFPS(fps int) Option {
+	return func(s *Spinner) error {
+		if fps < 1 || fps > maxFPS {
+			return fmt.Errorf("spinner: max fps should be in range 1..%v, given: %v", maxFPS, fps)
+		}
+		s.frameInterval = time.Second / time.Duration(fps)
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package spinner
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Prompt pauses the spinner, writes question and reads a line from Reader, spinner is resumed afterwards
+func (s *Spinner) Prompt(question string) (string, error) {
+	s.pl.Lock()
+	defer s.pl.Unlock()
+	return s.prompt(question)
+}
+
+// Confirm asks yes/no question, empty answer means no
+func (s *Spinner) Confirm(question string) (bool, error) {
+	s.pl.Lock()
+	defer s.pl.Unlock()
+	for {
+		answer, err := s.prompt(question + " [y/N] ")
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "", "n", "no":
+			return false, err
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+func (s *Spinner) prompt(question string) (string, error) {
+	// Note: external prompt lock
+	if !s.Paused() {
+		s.Pause()
+		defer s.Resume()
+	}
+	s.l.Lock()
+	if s.jsonOutput {
+		// the question is written as prompt event, so it is not lost in machine-readable output
+		s.writeJSONPrompt(question)
+	} else {
+		s.write(question)
+	}
+	if s.input == nil || s.inputSource != s.Reader {
+		s.input = bufio.NewReader(s.Reader)
+		s.inputSource = s.Reader
+	}
+	input := s.input
+	s.l.Unlock()
+
+	line, err := input.ReadString('\n')
+	line = strings.TrimRight(line, "\r\n")
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return line, err
+}
@@ -0,0 +1,123 @@
+package spinner
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr error
+	}{
+		{
+			"lines",
+			"first\nsecond\r\n",
+			[]string{"first", "second"},
+			nil,
+		},
+		{
+			"no trailing newline",
+			"first\nsecond",
+			[]string{"first", "second"},
+			nil,
+		},
+		{
+			"end of input",
+			"first\n",
+			[]string{"first", ""},
+			io.EOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New()
+			if err != nil {
+				t.Errorf("Unexpected error (%v)", err)
+				return
+			}
+			buffer := &syncBuffer{}
+			s.Writer = buffer
+			s.Reader = strings.NewReader(tt.input)
+			s.interval = 1 * time.Millisecond
+			s.Start()
+			var err2 error
+			for i, want := range tt.want {
+				var got string
+				got, err2 = s.Prompt("Question? ")
+				if got != want {
+					t.Errorf("Prompt() #%v = %q, want %q", i, got, want)
+				}
+				if !s.Active() || s.Paused() {
+					t.Errorf("Expected spinner to be resumed")
+				}
+			}
+			s.Stop()
+			if err2 != tt.wantErr {
+				t.Errorf("Prompt() error = %v, want %v", err2, tt.wantErr)
+			}
+			buffer.Lock()
+			output := buffer.String()
+			buffer.Unlock()
+			if !strings.Contains(output, "\x1b[?25hQuestion? ") {
+				t.Errorf("Expected question to be written with visible cursor, given: %q", output)
+			}
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    bool
+		wantErr bool
+	}{
+		{"yes", "y\n", true, false},
+		{"yes in upper case", "YES\n", true, false},
+		{"no", "no\n", false, false},
+		{"empty answer", "\n", false, false},
+		{"asks again", "maybe\ny\n", true, false},
+		{"end of input", "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New()
+			if err != nil {
+				t.Errorf("Unexpected error (%v)", err)
+				return
+			}
+			s.Writer = &syncBuffer{}
+			s.Reader = strings.NewReader(tt.input)
+			got, err := s.Confirm("Continue?")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Confirm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromptJSONOutput(t *testing.T) {
+	buffer := &syncBuffer{}
+	s, err := New(Output(buffer), JSONOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Reader = strings.NewReader("yes\n")
+	s.Start()
+	answer, err := s.Prompt("Continue? ")
+	s.Stop()
+	if err != nil || answer != "yes" {
+		t.Errorf("Prompt() = %q, %v", answer, err)
+	}
+	if out := buffer.String(); !strings.Contains(out, `"event":"prompt","message":"Continue?"`) {
+		t.Errorf("question is not written: %q", out)
+	}
+}
@@ -0,0 +1,122 @@
+package spinner
+
+import (
+	"time"
+)
+
+// maxQueuedMessages is the max number of pending messages, the oldest ones are dropped if queue is full
+const maxQueuedMessages = 100
+
+// Message queue policies, see MessageQueue()
+const (
+	// QueueCoalesce drops intermediate messages, the pending message is replaced with the newest one,
+	// so the latest message is shown next
+	QueueCoalesce = 1 << iota
+	// QueueFlush makes Stop(), Succeed() and Fail() wait until pending messages are shown,
+	// otherwise pending messages are discarded
+	QueueFlush
+)
+
+// messageQueue delays messages so that each one is shown for at least min duration
+type messageQueue struct {
+	min     time.Duration
+	policy  int
+	pending []string
+	shownAt time.Time     // time current message was shown
+	timer   *time.Timer   // shows the next pending message, nil if there are no pending messages
+	drained chan struct{} // closed when pending messages are shown, nil if there are no pending messages
+	next    func()        // called by timer
+}
+
+// push adds message m to the queue, returns true if m should be shown immediately
+func (q *messageQueue) push(m string, now time.Time) bool {
+	// Note: external lock
+	if len(q.pending) == 0 && now.Sub(q.shownAt) >= q.min {
+		q.shownAt = now
+		return true
+	}
+	switch {
+	case len(q.pending) > 0 && q.policy&QueueCoalesce != 0:
+		q.pending[len(q.pending)-1] = m
+	case len(q.pending) >= maxQueuedMessages:
+		q.pending = append(q.pending[1:], m)
+	default:
+		q.pending = append(q.pending, m)
+	}
+	if q.timer == nil {
+		q.drained = make(chan struct{})
+		q.timer = time.AfterFunc(q.min-now.Sub(q.shownAt), q.next)
+	}
+	return false
+}
+
+// pop removes the next pending message, returns false if there is no message to show yet
+func (q *messageQueue) pop(now time.Time) (string, bool) {
+	// Note: external lock
+	if len(q.pending) == 0 || now.Sub(q.shownAt) < q.min {
+		// stale timer of cleared queue
+		return "", false
+	}
+	m := q.pending[0]
+	q.pending = q.pending[1:]
+	q.shownAt = now
+	if len(q.pending) > 0 {
+		q.timer.Reset(q.min)
+		return m, true
+	}
+	q.timer = nil
+	close(q.drained)
+	q.drained = nil
+	return m, true
+}
+
+// clear discards pending messages
+func (q *messageQueue) clear() {
+	// Note: external lock
+	q.pending = nil
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+		close(q.drained)
+		q.drained = nil
+	}
+}
+
+// showQueued shows the next pending message
+func (s *Spinner) showQueued() {
+	s.l.Lock()
+	m, ok := s.queue.pop(time.Now())
+	if ok {
+		s.setMessage(m)
+	}
+	s.l.Unlock()
+	if ok {
+		s.requestRedraw()
+		s.emit(Event{Type: MessageChanged, Message: m})
+	}
+}
+
+// flushMessages waits until pending messages are shown and the last one is shown for min duration
+// if QueueFlush policy is set and wait is true, pending messages are discarded then
+func (s *Spinner) flushMessages(wait bool) {
+	if s.queue == nil {
+		return
+	}
+	for wait && s.queue.policy&QueueFlush != 0 {
+		s.l.RLock()
+		drained := s.queue.drained
+		remaining := s.queue.min - time.Since(s.queue.shownAt)
+		s.l.RUnlock()
+		if drained != nil {
+			<-drained
+			continue
+		}
+		if remaining > 0 {
+			time.Sleep(remaining)
+		}
+		break
+	}
+	s.l.Lock()
+	s.queue.clear()
+	s.l.Unlock()
+}
@@ -0,0 +1,99 @@
+package spinner
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMessageQueue(t *testing.T) {
+	const min = 40 * time.Millisecond
+	tests := []struct {
+		name      string
+		policy    int
+		want      []string
+		wantFlush bool // Stop() waits for pending messages
+	}{
+		{"all", 0, []string{"1"}, false},
+		{"all flush", QueueFlush, []string{"1", "2", "3", "4"}, true},
+		{"coalesce", QueueCoalesce | QueueFlush, []string{"1", "4"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &eventRecorder{}
+			s, err := New(Output(ioutil.Discard), MessageQueue(min, tt.policy), OnEvent(r.handle))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.Start()
+			for _, m := range []string{"1", "2", "3", "4"} {
+				s.Message(m)
+			}
+			start := time.Now()
+			s.Stop()
+			elapsed := time.Since(start)
+			// discarded messages are not shown after Stop()
+			time.Sleep(2 * min)
+
+			var got []string
+			var shownAt []time.Time
+			r.Lock()
+			for _, e := range r.events {
+				if e.Type == MessageChanged {
+					got = append(got, e.Message)
+					shownAt = append(shownAt, e.Time)
+				}
+			}
+			r.Unlock()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %v, want %v", got, tt.want)
+			}
+			for i := 1; i < len(shownAt); i++ {
+				if d := shownAt[i].Sub(shownAt[i-1]); d < min {
+					t.Errorf("message %v is shown for %v, want at least %v", got[i-1], d, min)
+				}
+			}
+			if flush := elapsed >= time.Duration(len(tt.want))*min; flush != tt.wantFlush {
+				t.Errorf("Stop() took %v, flush %v, want %v", elapsed, flush, tt.wantFlush)
+			}
+		})
+	}
+}
+
+func TestMessageQueueLimit(t *testing.T) {
+	q := &messageQueue{min: time.Hour, next: func() {}}
+	now := time.Now()
+	q.push("first", now)
+	for i := 0; i < maxQueuedMessages+10; i++ {
+		q.push(strconv.Itoa(i), now)
+	}
+	defer q.clear()
+	if len(q.pending) != maxQueuedMessages {
+		t.Errorf("pending messages = %v, want %v", len(q.pending), maxQueuedMessages)
+	}
+	// the oldest messages are dropped
+	first, last := q.pending[0], q.pending[len(q.pending)-1]
+	if first != "10" || last != strconv.Itoa(maxQueuedMessages+9) {
+		t.Errorf("pending messages = %v..%v", first, last)
+	}
+}
+
+func TestMessageQueueIdle(t *testing.T) {
+	const min = 20 * time.Millisecond
+	s, err := New(Output(ioutil.Discard), MessageQueue(min, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Message("1")
+	time.Sleep(2 * min)
+	// message after min duration is shown immediately
+	s.Message("2")
+	s.l.RLock()
+	current := s.message.current
+	s.l.RUnlock()
+	if current != "2" {
+		t.Errorf("message = %q, want %q", current, "2")
+	}
+}
@@ -0,0 +1,94 @@
+package spinner
+
+import (
+	"fmt"
+	"sync"
+)
+
+// registry contains active spinners and tasks lists
+var registry = struct {
+	sync.Mutex
+	spinners map[*Spinner]struct{}
+	tasks    map[*Tasks]struct{}
+}{
+	spinners: map[*Spinner]struct{}{},
+	tasks:    map[*Tasks]struct{}{},
+}
+
+// register adds spinner to registry of active spinners
+func register(s *Spinner) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.spinners[s] = struct{}{}
+}
+
+// unregister removes spinner from registry of active spinners
+func unregister(s *Spinner) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.spinners, s)
+}
+
+// activeSpinners returns a snapshot of active spinners
+func activeSpinners() []*Spinner {
+	registry.Lock()
+	defer registry.Unlock()
+	r := make([]*Spinner, 0, len(registry.spinners))
+	for s := range registry.spinners {
+		r = append(r, s)
+	}
+	return r
+}
+
+// registerTasks adds tasks list to registry of active tasks lists
+func registerTasks(ts *Tasks) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.tasks[ts] = struct{}{}
+}
+
+// unregisterTasks removes tasks list from registry of active tasks lists
+func unregisterTasks(ts *Tasks) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.tasks, ts)
+}
+
+// activeTasks returns a snapshot of active tasks lists
+func activeTasks() []*Tasks {
+	registry.Lock()
+	defer registry.Unlock()
+	r := make([]*Tasks, 0, len(registry.tasks))
+	for ts := range registry.tasks {
+		r = append(r, ts)
+	}
+	return r
+}
+
+// StopAll stops all active spinners and rendering of running tasks lists
+func StopAll() {
+	for _, s := range activeSpinners() {
+		s.Stop()
+	}
+	for _, ts := range activeTasks() {
+		ts.stop()
+	}
+}
+
+// Recover stops all active spinners and tasks lists restoring the terminal and re-panics with the original value,
+// should be deferred e.g. in main()
+//
+//	defer spinner.Recover()
+func Recover() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	for _, s := range activeSpinners() {
+		s.finish(ResultFailed, "", fmt.Sprintf("panic: %v", r))
+	}
+	for _, ts := range activeTasks() {
+		ts.stop()
+	}
+	panic(r)
+}
@@ -0,0 +1,84 @@
+package spinner
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopAll(t *testing.T) {
+	var spinners []*Spinner
+	for i := 0; i < 3; i++ {
+		s, err := New(Interval(time.Second), FinalMessage("Done\n"))
+		if err != nil {
+			t.Errorf("Unexpected error (%v)", err)
+			return
+		}
+		s.Writer = &syncBuffer{}
+		s.Start()
+		spinners = append(spinners, s)
+	}
+	if n := len(activeSpinners()); n != 3 {
+		t.Errorf("Expected 3 active spinners, given: %v", n)
+	}
+	StopAll()
+	for i, s := range spinners {
+		if s.Active() {
+			t.Errorf("Expected spinner #%v to be inactive", i)
+		}
+	}
+	if n := len(activeSpinners()); n != 0 {
+		t.Errorf("Expected no active spinners, given: %v", n)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	r := &eventRecorder{}
+	s, err := New(Interval(time.Second), FinalMessage("Done\n"), OnEvent(r.handle))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		defer Recover()
+		s.Start()
+		panic("boom")
+	}()
+	if recovered != "boom" {
+		t.Errorf("Expected original panic value, given: %v", recovered)
+	}
+	if s.Active() {
+		t.Errorf("Expected spinner to be inactive")
+	}
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if strings.Contains(output, "Done") || !strings.HasSuffix(output, "\x1b[?25h") {
+		t.Errorf("Unexpected output: %q", output)
+	}
+	e := r.last()
+	if e.Type != Stopped || e.Result != ResultFailed || e.Message != "panic: boom" {
+		t.Errorf("Unexpected last event: %+v", e)
+	}
+}
+
+func TestRecoverWithoutPanic(t *testing.T) {
+	s, err := New(Interval(time.Second))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	s.Writer = &syncBuffer{}
+	func() {
+		defer Recover()
+		s.Start()
+	}()
+	if !s.Active() {
+		t.Errorf("Expected spinner to stay active")
+	}
+	s.Stop()
+}
@@ -0,0 +1,129 @@
+package render
+
+import (
+	"image"
+	imgcolor "image/color"
+	"image/color/palette"
+	"image/gif"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// maxGIFFrames is the max number of frames of GIF animation
+const maxGIFFrames = 1000
+
+// GIF writes animation as looped GIF image. Chars and colors are cycled together until both cycles
+// end, at most 1000 frames. Chars are drawn without fonts, see glyph() for supported runes
+func (a Animation) GIF(w io.Writer, s Style) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+	st, err := s.parse()
+	if err != nil {
+		return err
+	}
+	styles := a.styles(st)
+	columns, rows := a.size()
+	cw, ch := st.cell()
+	pad := st.padding()
+	bounds := image.Rect(0, 0, columns*cw+2*pad, rows*ch+2*pad)
+	text := image.Rect(pad, pad, pad+columns*cw, pad+rows*ch)
+	p := newPalette(st, styles)
+
+	n := lcm(len(a.Chars), len(styles))
+	if n > maxGIFFrames {
+		n = maxGIFFrames
+	}
+	// delay is in 100ths of a second, viewers slow down shorter delays
+	delay := int((a.Interval + 5*time.Millisecond) / (10 * time.Millisecond))
+	if delay < 2 {
+		delay = 2
+	}
+	g := &gif.GIF{}
+	last := -1
+	for i := 0; i < n; i++ {
+		c, cs := a.Chars[i%len(a.Chars)], styles[i%len(styles)]
+		// repeated frame extends the previous one
+		if last >= 0 && c == a.Chars[last%len(a.Chars)] && cs == styles[last%len(styles)] {
+			g.Delay[len(g.Delay)-1] += delay
+			continue
+		}
+		last = i
+		img := image.NewPaletted(bounds, p)
+		fill(img, bounds, uint8(p.Index(st.background)))
+		if cs.hasBg {
+			fill(img, text, uint8(p.Index(cs.bg)))
+		}
+		fg := uint8(p.Index(cs.fg))
+		for row, line := range strings.Split(c, "\n") {
+			x := pad
+			for _, r := range line {
+				rw := runewidth.RuneWidth(r)
+				if rw == 0 {
+					continue
+				}
+				if s := glyph(r); s != nil {
+					paint(img, s, image.Rect(x, pad+row*ch, x+rw*cw, pad+(row+1)*ch), fg)
+				}
+				x += rw * cw
+			}
+		}
+		g.Image = append(g.Image, img)
+		g.Delay = append(g.Delay, delay)
+	}
+	return gif.EncodeAll(w, g)
+}
+
+// newPalette returns palette of background and colors of styles, background is the first color.
+// Plan9 palette is used if there are more than 256 colors
+func newPalette(st style, styles []cellStyle) imgcolor.Palette {
+	p := imgcolor.Palette{st.background}
+	seen := map[imgcolor.RGBA]bool{st.background: true}
+	add := func(c imgcolor.RGBA) {
+		if !seen[c] {
+			seen[c] = true
+			p = append(p, c)
+		}
+	}
+	for _, c := range styles {
+		add(c.fg)
+		add(c.bg)
+	}
+	if len(p) > 256 {
+		return palette.Plan9
+	}
+	return p
+}
+
+// fill fills rectangle r of img with color index idx
+func fill(img *image.Paletted, r image.Rectangle, idx uint8) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetColorIndex(x, y, idx)
+		}
+	}
+}
+
+// paint paints shape s in cell r of img with color index idx
+func paint(img *image.Paletted, s shape, r image.Rectangle, idx uint8) {
+	w, h := float64(r.Dx()), float64(r.Dy())
+	for y := 0; y < r.Dy(); y++ {
+		for x := 0; x < r.Dx(); x++ {
+			if s(float64(x)+0.5, float64(y)+0.5, w, h) {
+				img.SetColorIndex(r.Min.X+x, r.Min.Y+y, idx)
+			}
+		}
+	}
+}
+
+// lcm returns least common multiple of a and b
+func lcm(a, b int) int {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
@@ -0,0 +1,325 @@
+package render
+
+import (
+	"math"
+)
+
+// shape reports whether pixel with center at x, y of w x h cell is painted
+type shape func(x, y, w, h float64) bool
+
+// glyph returns shape of rune r, nil for blank runes. Runes of built-in char sets are drawn as shapes:
+// ASCII, braille, block elements, box drawing, geometric shapes, arrows and clock faces, other runes
+// are drawn as empty box
+func glyph(r rune) shape {
+	if rows, ok := font5x7[r]; ok {
+		return bitmap(rows)
+	}
+	switch {
+	case r == ' ' || r == 0x2800:
+		return nil
+	case r > 0x2800 && r <= 0x28ff:
+		return braille(r - 0x2800)
+	case r >= 0x2580 && r <= 0x259f:
+		return block(r)
+	case boxArms[r] != 0:
+		return box(boxArms[r])
+	case arrows[r] != [2]float64{}:
+		d := arrows[r]
+		return arrow(d[0], d[1], r >= 0x21d0)
+	case r >= 0x1f550 && r <= 0x1f567:
+		return clock(r - 0x1f550)
+	}
+	if s := geometric(r); s != nil {
+		return s
+	}
+	return tofu
+}
+
+// thickness returns width of lines in cell
+func thickness(w, h float64) float64 {
+	return math.Max(1, math.Min(w, h)/8)
+}
+
+// rect returns shape of rectangle, bounds are fractions of cell size
+func rect(x0, y0, x1, y1 float64) shape {
+	return func(x, y, w, h float64) bool {
+		return x >= x0*w && x < x1*w && y >= y0*h && y < y1*h
+	}
+}
+
+// union returns shape painted if any of shapes is painted
+func union(shapes ...shape) shape {
+	return func(x, y, w, h float64) bool {
+		for _, s := range shapes {
+			if s(x, y, w, h) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// bitmap returns shape of 5x7 bitmap, left and top margin is one bitmap pixel
+func bitmap(rows [7]string) shape {
+	return func(x, y, w, h float64) bool {
+		col := int(x/w*7) - 1
+		row := int(y/h*9) - 1
+		return row >= 0 && row < 7 && col >= 0 && col < 5 && rows[row][col] == '#'
+	}
+}
+
+// brailleDots contains column and row of braille dots in order of bits
+var brailleDots = [8][2]float64{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {0, 3}, {1, 3}}
+
+// braille returns shape of braille pattern with dots bits
+func braille(bits rune) shape {
+	return func(x, y, w, h float64) bool {
+		r := w * 0.17
+		for i, d := range brailleDots {
+			if bits&(1<<uint(i)) == 0 {
+				continue
+			}
+			dx, dy := x-w*(0.3+0.4*d[0]), y-h*(0.17+0.22*d[1])
+			if dx*dx+dy*dy <= r*r+0.25 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// quadrants contains quadrants of block elements starting from U+2596, bits: 1 - upper left,
+// 2 - upper right, 4 - lower left, 8 - lower right
+var quadrants = [10]int{4, 8, 1, 1 | 4 | 8, 1 | 8, 1 | 2 | 4, 1 | 2 | 8, 2, 2 | 4, 2 | 4 | 8}
+
+// block returns shape of block element r
+func block(r rune) shape {
+	switch {
+	case r == 0x2580:
+		return rect(0, 0, 1, 0.5)
+	case r <= 0x2588:
+		return rect(0, 1-float64(r-0x2580)/8, 1, 1)
+	case r <= 0x258f:
+		return rect(0, 0, float64(0x2590-r)/8, 1)
+	case r == 0x2590:
+		return rect(0.5, 0, 1, 1)
+	case r <= 0x2593:
+		return shade(int(r - 0x2590))
+	case r == 0x2594:
+		return rect(0, 0, 1, 0.125)
+	case r == 0x2595:
+		return rect(0.875, 0, 1, 1)
+	}
+	q := quadrants[r-0x2596]
+	var shapes []shape
+	for i, s := range []shape{rect(0, 0, 0.5, 0.5), rect(0.5, 0, 1, 0.5), rect(0, 0.5, 0.5, 1), rect(0.5, 0.5, 1, 1)} {
+		if q&(1<<uint(i)) != 0 {
+			shapes = append(shapes, s)
+		}
+	}
+	return union(shapes...)
+}
+
+// shade returns dither pattern of shade level 1 - light, 2 - medium, 3 - dark
+func shade(level int) shape {
+	return func(x, y, w, h float64) bool {
+		px, py := int(x), int(y)
+		light := px%2 == 0 && py%2 == 0
+		switch level {
+		case 1:
+			return light
+		case 2:
+			return (px+py)%2 == 0
+		}
+		return !light
+	}
+}
+
+// boxArms contains arms of box drawing chars, bits: 1 - up, 2 - down, 4 - left, 8 - right
+var boxArms = map[rune]int{
+	0x2500: 4 | 8,
+	0x2502: 1 | 2,
+	0x250c: 2 | 8,
+	0x2510: 2 | 4,
+	0x2514: 1 | 8,
+	0x2518: 1 | 4,
+	0x251c: 1 | 2 | 8,
+	0x2524: 1 | 2 | 4,
+	0x252c: 2 | 4 | 8,
+	0x2534: 1 | 4 | 8,
+	0x253c: 1 | 2 | 4 | 8,
+}
+
+// box returns shape of box drawing char with arms from the cell center
+func box(arms int) shape {
+	return func(x, y, w, h float64) bool {
+		t := thickness(w, h) / 2
+		cx, cy := w/2, h/2
+		vertical := math.Abs(x-cx) <= t
+		horizontal := math.Abs(y-cy) <= t
+		return arms&1 != 0 && vertical && y <= cy+t ||
+			arms&2 != 0 && vertical && y >= cy-t ||
+			arms&4 != 0 && horizontal && x <= cx+t ||
+			arms&8 != 0 && horizontal && x >= cx-t
+	}
+}
+
+// geometric returns shape of geometric shape r, nil if r is not supported
+func geometric(r rune) shape {
+	switch r {
+	case 0x25a0:
+		return square(0.7, true)
+	case 0x25a1:
+		return square(0.7, false)
+	case 0x25aa:
+		return square(0.45, true)
+	case 0x25ab:
+		return square(0.45, false)
+	case 0x25b2, 0x25b3, 0x25b4, 0x25b5:
+		return triangle(0, -1, r <= 0x25b3, r%2 == 0)
+	case 0x25b6, 0x25b7, 0x25b8, 0x25b9:
+		return triangle(1, 0, r <= 0x25b7, r%2 == 0)
+	case 0x25bc, 0x25bd, 0x25be, 0x25bf:
+		return triangle(0, 1, r <= 0x25bd, r%2 == 0)
+	case 0x25c0, 0x25c1, 0x25c2, 0x25c3:
+		return triangle(-1, 0, r <= 0x25c1, r%2 == 0)
+	case 0x25cb:
+		return circle(func(dx, dy float64) bool { return false })
+	case 0x25cf:
+		return circle(func(dx, dy float64) bool { return true })
+	case 0x25d0:
+		return circle(func(dx, dy float64) bool { return dx <= 0 })
+	case 0x25d1:
+		return circle(func(dx, dy float64) bool { return dx >= 0 })
+	case 0x25d2:
+		return circle(func(dx, dy float64) bool { return dy >= 0 })
+	case 0x25d3:
+		return circle(func(dx, dy float64) bool { return dy <= 0 })
+	}
+	return nil
+}
+
+// square returns shape of square with side of size fraction of cell width
+func square(size float64, filled bool) shape {
+	return func(x, y, w, h float64) bool {
+		a := w * size / 2
+		dx, dy := math.Abs(x-w/2), math.Abs(y-h/2)
+		inside := dx <= a && dy <= a
+		if filled || !inside {
+			return inside
+		}
+		t := thickness(w, h)
+		return dx > a-t || dy > a-t
+	}
+}
+
+// triangle returns shape of triangle pointing to direction dx, dy
+func triangle(dx, dy float64, large, filled bool) shape {
+	return func(x, y, w, h float64) bool {
+		a := w * 0.28
+		if large {
+			a = w * 0.42
+		}
+		// rotate to point right
+		u, v := x-w/2, y-h/2
+		u, v = u*dx+v*dy, v*dx-u*dy
+		inside := func(a float64) bool {
+			return u >= -a && u <= a && math.Abs(v) <= (a-u)/2
+		}
+		if filled {
+			return inside(a)
+		}
+		return inside(a) && !inside(a-1.5*thickness(w, h))
+	}
+}
+
+// circle returns shape of ring with area filled where filled reports true, dx, dy are offsets from center
+func circle(filled func(dx, dy float64) bool) shape {
+	return func(x, y, w, h float64) bool {
+		r := w * 0.4
+		dx, dy := x-w/2, y-h/2
+		d := math.Sqrt(dx*dx + dy*dy)
+		if d > r {
+			return false
+		}
+		return d > r-thickness(w, h) || filled(dx, dy)
+	}
+}
+
+// arrows contains directions of arrows, y axis points down
+var arrows = map[rune][2]float64{
+	0x2190: {-1, 0}, 0x2191: {0, -1}, 0x2192: {1, 0}, 0x2193: {0, 1},
+	0x2196: {-1, -1}, 0x2197: {1, -1}, 0x2198: {1, 1}, 0x2199: {-1, 1},
+	0x21d0: {-1, 0}, 0x21d1: {0, -1}, 0x21d2: {1, 0}, 0x21d3: {0, 1},
+	0x21d6: {-1, -1}, 0x21d7: {1, -1}, 0x21d8: {1, 1}, 0x21d9: {-1, 1},
+}
+
+// arrow returns shape of arrow pointing to direction dx, dy, double arrow has two shafts
+func arrow(dx, dy float64, double bool) shape {
+	n := math.Hypot(dx, dy)
+	dx, dy = dx/n, dy/n
+	return func(x, y, w, h float64) bool {
+		t := thickness(w, h)
+		l := w * 0.42
+		cx, cy := w/2, h/2
+		tipX, tipY := cx+dx*l, cy+dy*l
+		// head arms are rotated back by 45 degrees
+		const c = math.Sqrt2 / 2
+		for _, s := range []float64{-1, 1} {
+			ax, ay := -dx*c-s*dy*c, -dy*c+s*dx*c
+			if segmentDistance(x, y, tipX, tipY, tipX+ax*l*0.7, tipY+ay*l*0.7) <= t/2 {
+				return true
+			}
+		}
+		if !double {
+			return segmentDistance(x, y, cx-dx*l, cy-dy*l, tipX, tipY) <= t/2
+		}
+		for _, s := range []float64{-1, 1} {
+			ox, oy := -dy*t*s, dx*t*s
+			if segmentDistance(x, y, cx-dx*l+ox, cy-dy*l+oy, tipX-dx*t*1.5+ox, tipY-dy*t*1.5+oy) <= t/2 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// clock returns shape of clock face, i is offset from U+1F550 (one o'clock)
+func clock(i rune) shape {
+	hour, minute := float64(i%12+1), 0.0
+	if i >= 12 {
+		minute = 30
+	}
+	return func(x, y, w, h float64) bool {
+		t := thickness(w, h)
+		r := math.Min(w, h) * 0.42
+		cx, cy := w/2, h/2
+		dx, dy := x-cx, y-cy
+		if d := math.Hypot(dx, dy); d <= r && d > r-t {
+			return true
+		}
+		hand := func(angle, length float64) bool {
+			a := angle * math.Pi / 180
+			return segmentDistance(x, y, cx, cy, cx+math.Sin(a)*length, cy-math.Cos(a)*length) <= t/2
+		}
+		return hand((hour+minute/60)*30, r*0.5) || hand(minute*6, r*0.8)
+	}
+}
+
+// tofu is shape of unsupported rune
+func tofu(x, y, w, h float64) bool {
+	x0, y0, x1, y1 := 0.15*w, 0.2*h, 0.85*w, 0.85*h
+	inside := x >= x0 && x < x1 && y >= y0 && y < y1
+	return inside && (x < x0+1 || x >= x1-1 || y < y0+1 || y >= y1-1)
+}
+
+// segmentDistance returns distance from point x, y to segment ax, ay - bx, by
+func segmentDistance(x, y, ax, ay, bx, by float64) float64 {
+	vx, vy := bx-ax, by-ay
+	l := vx*vx + vy*vy
+	p := 0.0
+	if l > 0 {
+		p = math.Max(0, math.Min(1, ((x-ax)*vx+(y-ay)*vy)/l))
+	}
+	return math.Hypot(x-ax-p*vx, y-ay-p*vy)
+}
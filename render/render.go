@@ -0,0 +1,230 @@
+// Package render renders spinner char sets as animated SVG or GIF images, e.g. for documentation
+//
+//	a, _ := render.Variant(spinner.Dots14, color.C256Rainbow)
+//	_ = a.SVG(w, render.Style{FontSize: 24})
+package render
+
+import (
+	"fmt"
+	imgcolor "image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+const (
+	defaultFontSize   = 16
+	defaultBackground = "#1e1e1e"
+	defaultForeground = "#d0d0d0"
+	// cell size relative to font size, typical for monospace fonts
+	cellWidth  = 0.6
+	cellHeight = 1.25
+)
+
+// Animation represents chars cycled every Interval, colorized by ColorSet the way spinner colorizes chars
+type Animation struct {
+	Chars    []string
+	Interval time.Duration
+	ColorSet int // key of color.Prototypes
+}
+
+// Style represents look of rendered animation
+type Style struct {
+	FontSize   int    // font size in pixels, 16 if not set
+	Background string // background color #rrggbb, #1e1e1e if not set
+	Foreground string // color of chars which are not colorized, #d0d0d0 if not set
+}
+
+// cellStyle represents colors of chars in a frame
+type cellStyle struct {
+	fg    imgcolor.RGBA
+	bg    imgcolor.RGBA
+	hasBg bool
+}
+
+// Variant returns animation of spinner variant v colorized by color set c
+func Variant(v, c int) (Animation, error) {
+	chars, interval, ok := spinner.VariantChars(v)
+	if !ok {
+		return Animation{}, fmt.Errorf("render: unknown variant: %v", v)
+	}
+	return Animation{Chars: chars, Interval: interval, ColorSet: c}, nil
+}
+
+// validate checks animation
+func (a Animation) validate() error {
+	if len(a.Chars) == 0 {
+		return fmt.Errorf("render: chars are empty")
+	}
+	if a.Interval <= 0 {
+		return fmt.Errorf("render: interval should be positive, got %v", a.Interval)
+	}
+	if _, ok := color.Prototypes[a.ColorSet]; !ok {
+		return fmt.Errorf("render: unknown color set: %v", a.ColorSet)
+	}
+	return nil
+}
+
+// styles returns colors of color set applied over style s
+func (a Animation) styles(s style) []cellStyle {
+	p := color.Prototypes[a.ColorSet]
+	formats := p.Handler(p.ANSIStyles)
+	r := make([]cellStyle, len(formats))
+	for i, f := range formats {
+		r[i] = parseSGR(fmt.Sprintf(f, ""), s)
+	}
+	return r
+}
+
+// size returns size of the largest frame in columns and rows
+func (a Animation) size() (columns, rows int) {
+	return spinner.CharSetSize(a.Chars)
+}
+
+// style is Style with defaults applied and colors parsed
+type style struct {
+	fontSize   int
+	background imgcolor.RGBA
+	foreground imgcolor.RGBA
+}
+
+// parse applies defaults and parses colors of s
+func (s Style) parse() (style, error) {
+	r := style{fontSize: s.FontSize}
+	if r.fontSize == 0 {
+		r.fontSize = defaultFontSize
+	}
+	if r.fontSize < 4 {
+		return r, fmt.Errorf("render: font size is too small: %v", s.FontSize)
+	}
+	var err error
+	if r.background, err = parseHex(s.Background, defaultBackground); err != nil {
+		return r, err
+	}
+	if r.foreground, err = parseHex(s.Foreground, defaultForeground); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// cell returns size of char cell in pixels
+func (s style) cell() (w, h int) {
+	return int(float64(s.fontSize)*cellWidth + 0.5), int(float64(s.fontSize)*cellHeight + 0.5)
+}
+
+// padding returns padding around frame in pixels
+func (s style) padding() int {
+	return s.fontSize / 2
+}
+
+// parseHex parses color #rrggbb or #rgb, def is used if v is empty
+func parseHex(v, def string) (imgcolor.RGBA, error) {
+	if v == "" {
+		v = def
+	}
+	h := strings.TrimPrefix(v, "#")
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	n, err := strconv.ParseUint(h, 16, 32)
+	if err != nil || len(h) != 6 || !strings.HasPrefix(v, "#") {
+		return imgcolor.RGBA{}, fmt.Errorf("render: invalid color: %v, expected #rrggbb", v)
+	}
+	return imgcolor.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 0xff}, nil
+}
+
+// hex returns color as #rrggbb
+func hex(c imgcolor.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// parseSGR returns colors set by select graphic rendition sequences of v, colors of s are default
+func parseSGR(v string, s style) cellStyle {
+	r := cellStyle{fg: s.foreground, bg: s.background}
+	dim := false
+	for _, seq := range strings.Split(v, "\x1b[")[1:] {
+		end := strings.IndexByte(seq, 'm')
+		if end < 0 {
+			continue
+		}
+		var params []int
+		for _, p := range strings.Split(seq[:end], ";") {
+			n, _ := strconv.Atoi(p)
+			params = append(params, n)
+		}
+		for i := 0; i < len(params); i++ {
+			switch p := params[i]; {
+			case p == 0:
+				// reset at the end of style is ignored
+			case p == 2:
+				dim = true
+			case p >= 30 && p <= 37:
+				r.fg = ansi256(p - 30)
+			case p >= 90 && p <= 97:
+				r.fg = ansi256(p - 90 + 8)
+			case p >= 40 && p <= 47:
+				r.bg, r.hasBg = ansi256(p-40), true
+			case p >= 100 && p <= 107:
+				r.bg, r.hasBg = ansi256(p-100+8), true
+			case (p == 38 || p == 48) && i+2 < len(params) && params[i+1] == 5:
+				c := ansi256(params[i+2])
+				if p == 38 {
+					r.fg = c
+				} else {
+					r.bg, r.hasBg = c, true
+				}
+				i += 2
+			case (p == 38 || p == 48) && i+4 < len(params) && params[i+1] == 2:
+				c := imgcolor.RGBA{R: uint8(params[i+2]), G: uint8(params[i+3]), B: uint8(params[i+4]), A: 0xff}
+				if p == 38 {
+					r.fg = c
+				} else {
+					r.bg, r.hasBg = c, true
+				}
+				i += 4
+			}
+		}
+	}
+	if dim {
+		r.fg = blend(r.fg, r.bg)
+	}
+	return r
+}
+
+// blend returns color halfway between a and b
+func blend(a, b imgcolor.RGBA) imgcolor.RGBA {
+	return imgcolor.RGBA{
+		R: uint8((int(a.R) + int(b.R)) / 2),
+		G: uint8((int(a.G) + int(b.G)) / 2),
+		B: uint8((int(a.B) + int(b.B)) / 2),
+		A: 0xff,
+	}
+}
+
+// ansi16 contains xterm colors of 16 color palette
+var ansi16 = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansi256 returns xterm color n of 256 color palette
+func ansi256(n int) imgcolor.RGBA {
+	switch {
+	case n < 0 || n > 255:
+		n = 7
+		fallthrough
+	case n < 16:
+		c := ansi16[n]
+		return imgcolor.RGBA{R: c[0], G: c[1], B: c[2], A: 0xff}
+	case n < 232:
+		levels := [6]uint8{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return imgcolor.RGBA{R: levels[n/36], G: levels[n/6%6], B: levels[n%6], A: 0xff}
+	default:
+		g := uint8(8 + 10*(n-232))
+		return imgcolor.RGBA{R: g, G: g, B: g, A: 0xff}
+	}
+}
@@ -0,0 +1,173 @@
+package render
+
+import (
+	"bytes"
+	imgcolor "image/color"
+	"image/gif"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+func TestParseSGR(t *testing.T) {
+	st := style{background: imgcolor.RGBA{A: 0xff}, foreground: imgcolor.RGBA{R: 200, G: 200, B: 200, A: 0xff}}
+	tests := []struct {
+		name string
+		v    string
+		want cellStyle
+	}{
+		{"none", "", cellStyle{fg: st.foreground, bg: st.background}},
+		{"16", "\x1b[96m\x1b[0m", cellStyle{fg: imgcolor.RGBA{G: 255, B: 255, A: 0xff}, bg: st.background}},
+		{"256", "\x1b[38;5;196m\x1b[0m", cellStyle{fg: imgcolor.RGBA{R: 255, A: 0xff}, bg: st.background}},
+		{"256 background", "\x1b[38;5;16;48;5;232;3m\x1b[0m",
+			cellStyle{fg: imgcolor.RGBA{A: 0xff}, bg: imgcolor.RGBA{R: 8, G: 8, B: 8, A: 0xff}, hasBg: true}},
+		{"truecolor", "\x1b[38;2;1;2;3m\x1b[0m", cellStyle{fg: imgcolor.RGBA{R: 1, G: 2, B: 3, A: 0xff}, bg: st.background}},
+		{"dim", "\x1b[2m\x1b[0m", cellStyle{fg: imgcolor.RGBA{R: 100, G: 100, B: 100, A: 0xff}, bg: st.background}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSGR(tt.v, st); got != tt.want {
+				t.Errorf("parseSGR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       Style
+		want    style
+		wantErr bool
+	}{
+		{"defaults", Style{}, style{
+			fontSize:   16,
+			background: imgcolor.RGBA{R: 0x1e, G: 0x1e, B: 0x1e, A: 0xff},
+			foreground: imgcolor.RGBA{R: 0xd0, G: 0xd0, B: 0xd0, A: 0xff},
+		}, false},
+		{"short color", Style{FontSize: 32, Background: "#fff", Foreground: "#000000"}, style{
+			fontSize:   32,
+			background: imgcolor.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+			foreground: imgcolor.RGBA{A: 0xff},
+		}, false},
+		{"font size", Style{FontSize: 2}, style{}, true},
+		{"no hash", Style{Background: "ffffff"}, style{}, true},
+		{"invalid color", Style{Foreground: "#ggg"}, style{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlyphsOfVariants(t *testing.T) {
+	for _, name := range spinner.VariantNames() {
+		v, _ := spinner.VariantByName(name)
+		chars, _, _ := spinner.VariantChars(v)
+		for _, c := range chars {
+			for _, r := range c {
+				if r == '\n' {
+					continue
+				}
+				if g := glyph(r); g != nil && reflect.ValueOf(g).Pointer() == reflect.ValueOf(shape(tofu)).Pointer() {
+					t.Errorf("variant %v: rune %U is not supported", name, r)
+				}
+			}
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	a := Animation{Chars: []string{"<a>", "b  "}, Interval: 100 * time.Millisecond, ColorSet: color.C256RSingle}
+	var b bytes.Buffer
+	if err := a.SVG(&b, Style{}); err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+	for _, want := range []string{
+		`width="46" height="36"`,
+		"animation: frame 200ms step-end infinite",
+		"@keyframes frame { 0% { visibility: visible; } 50% { visibility: hidden; } }",
+		"animation: fg 3000ms step-end infinite",
+		"3.3333% { fill: #ff5f00; }",
+		"@keyframes bg { 0% { fill: #080808; } }",
+		`style="animation-delay: 100ms"><tspan x="8" y="22">b  </tspan>`,
+		"&lt;a&gt;",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %q:\n%s", want, svg)
+		}
+	}
+	if err := (Animation{Interval: time.Second}).SVG(&b, Style{}); err == nil {
+		t.Errorf("empty chars are accepted")
+	}
+}
+
+func TestGIF(t *testing.T) {
+	tests := []struct {
+		name       string
+		a          Animation
+		wantFrames int
+		wantDelay  int
+	}{
+		{"no color", Animation{Chars: []string{"|", "/", "-", "\\"}, Interval: 120 * time.Millisecond}, 4, 48},
+		{"repeated frames", Animation{Chars: []string{"+", "+", "x"}, Interval: 100 * time.Millisecond}, 2, 30},
+		{"colors", Animation{Chars: []string{"⠋", "⠙"}, Interval: 100 * time.Millisecond, ColorSet: color.C256YellowWhite}, 0, 0},
+		{"multi-line", Animation{Chars: []string{"▗▖\n▝▘", "▖▗\n▘▝"}, Interval: 20 * time.Millisecond}, 2, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.a.GIF(&b, Style{FontSize: 10}); err != nil {
+				t.Fatal(err)
+			}
+			g, err := gif.DecodeAll(&b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			columns, rows := tt.a.size()
+			if bounds := g.Image[0].Bounds(); bounds.Dx() != columns*6+10 || bounds.Dy() != rows*13+10 {
+				t.Errorf("bounds = %v", bounds)
+			}
+			if tt.wantFrames == 0 {
+				p := color.Prototypes[tt.a.ColorSet]
+				tt.wantFrames = lcm(len(tt.a.Chars), len(p.Handler(p.ANSIStyles)))
+				tt.wantDelay = tt.wantFrames * 10
+			}
+			if len(g.Image) != tt.wantFrames {
+				t.Errorf("frames = %v, want %v", len(g.Image), tt.wantFrames)
+			}
+			delay := 0
+			for _, d := range g.Delay {
+				delay += d
+			}
+			if delay != tt.wantDelay {
+				t.Errorf("total delay = %v, want %v", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestVariant(t *testing.T) {
+	a, err := Variant(spinner.Snake2, color.CLightCyan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Chars) != 8 || a.Interval != 120*time.Millisecond {
+		t.Errorf("Variant() = %v", a)
+	}
+	if _, err := Variant(-1, color.CNoColor); err == nil {
+		t.Errorf("unknown variant is accepted")
+	}
+}
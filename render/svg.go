@@ -0,0 +1,105 @@
+package render
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SVG writes animation as SVG image animated by CSS keyframes. Chars and colors are cycled
+// independently as spinner does, chars are rendered by monospace font of viewer
+func (a Animation) SVG(w io.Writer, s Style) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+	st, err := s.parse()
+	if err != nil {
+		return err
+	}
+	styles := a.styles(st)
+	columns, rows := a.size()
+	cw, ch := st.cell()
+	pad := st.padding()
+	width, height := columns*cw+2*pad, rows*ch+2*pad
+	charsCycle := ms(a.Interval * time.Duration(len(a.Chars)))
+	colorsCycle := ms(a.Interval * time.Duration(len(styles)))
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	fmt.Fprintln(b, "<style>")
+	fmt.Fprintf(b, "text { font-family: monospace; font-size: %dpx; white-space: pre; visibility: hidden;"+
+		" animation: frame %vms step-end infinite; }\n", st.fontSize, charsCycle)
+	fmt.Fprintf(b, "@keyframes frame { 0%% { visibility: visible; } %s%% { visibility: hidden; } }\n",
+		percent(1, len(a.Chars)))
+	if len(styles) == 1 {
+		fmt.Fprintf(b, ".fg { fill: %s; }\n", hex(styles[0].fg))
+	} else {
+		fmt.Fprintf(b, ".fg { fill: %s; animation: fg %vms step-end infinite; }\n", hex(styles[0].fg), colorsCycle)
+		fmt.Fprintf(b, "@keyframes fg {%s }\n", keyframes(styles, "fill", func(c cellStyle) string {
+			return hex(c.fg)
+		}))
+	}
+	hasBg := false
+	for _, c := range styles {
+		hasBg = hasBg || c.hasBg
+	}
+	if hasBg {
+		fmt.Fprintf(b, ".bg { animation: bg %vms step-end infinite; }\n", colorsCycle)
+		fmt.Fprintf(b, "@keyframes bg {%s }\n", keyframes(styles, "fill", func(c cellStyle) string {
+			return hex(c.bg)
+		}))
+	}
+	fmt.Fprintln(b, "</style>")
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(st.background))
+	if hasBg {
+		fmt.Fprintf(b, `<rect class="bg" x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			pad, pad, columns*cw, rows*ch, hex(st.background))
+	}
+	fmt.Fprintln(b, `<g class="fg">`)
+	// baseline of the first row, ascent of monospace fonts is about 0.8 of font size
+	baseline := pad + (ch-st.fontSize)/2 + st.fontSize*4/5
+	for i, c := range a.Chars {
+		fmt.Fprintf(b, `<text xml:space="preserve" style="animation-delay: %vms">`, ms(a.Interval*time.Duration(i)))
+		for j, line := range strings.Split(c, "\n") {
+			fmt.Fprintf(b, `<tspan x="%d" y="%d">`, pad, baseline+j*ch)
+			if err := xml.EscapeText(b, []byte(line)); err != nil {
+				return err
+			}
+			fmt.Fprint(b, "</tspan>")
+		}
+		fmt.Fprintln(b, "</text>")
+	}
+	fmt.Fprintln(b, "</g>")
+	fmt.Fprintln(b, "</svg>")
+	return b.Flush()
+}
+
+// keyframes returns keyframes setting property to value of every style, repeated values are skipped
+func keyframes(styles []cellStyle, property string, value func(cellStyle) string) string {
+	var b strings.Builder
+	last := ""
+	for i, c := range styles {
+		v := value(c)
+		if i > 0 && v == last {
+			continue
+		}
+		last = v
+		fmt.Fprintf(&b, " %s%% { %s: %s; }", percent(i, len(styles)), property, v)
+	}
+	return b.String()
+}
+
+// percent returns i/n in percent with trailing zeroes trimmed
+func percent(i, n int) string {
+	p := fmt.Sprintf("%.4f", float64(i)*100/float64(n))
+	return strings.TrimSuffix(strings.TrimRight(p, "0"), ".")
+}
+
+// ms returns d in milliseconds
+func ms(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
@@ -0,0 +1,100 @@
+package spinner
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// interruptedMessage is printed by spinners interrupted by signal
+const interruptedMessage = "Interrupted"
+
+// exitSignals contains signals handled by signal handler
+var exitSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// signals holds state of signal handler shared by all spinners
+var signals = struct {
+	sync.Mutex
+	count int            // number of handler users
+	c     chan os.Signal // notification channel, nil if handler is not installed
+}{}
+
+// raise re-raises signal sig after handler is uninstalled
+var raise = func(sig os.Signal) {
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(sig)
+	}
+	if err != nil {
+		// signal can't be delivered, e.g. os.Interrupt on windows
+		os.Exit(exitCode(sig))
+	}
+}
+
+// RestoreOnExit installs handler of SIGINT and SIGTERM, on signal all active spinners and tasks lists
+// are interrupted, cursor is shown and the signal is re-raised. Call returned function to uninstall handler
+func RestoreOnExit() (uninstall func()) {
+	return installSignalHandler()
+}
+
+// installSignalHandler installs shared signal handler and returns function to release it
+func installSignalHandler() func() {
+	signals.Lock()
+	defer signals.Unlock()
+	signals.count++
+	if signals.c == nil {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, exitSignals...)
+		signals.c = c
+		go handleSignals(c)
+	}
+	var once sync.Once
+	return func() {
+		once.Do(releaseSignalHandler)
+	}
+}
+
+// releaseSignalHandler uninstalls signal handler when it has no more users
+func releaseSignalHandler() {
+	signals.Lock()
+	defer signals.Unlock()
+	if signals.count > 0 {
+		signals.count--
+	}
+	if signals.count == 0 && signals.c != nil {
+		signal.Stop(signals.c)
+		close(signals.c)
+		signals.c = nil
+	}
+}
+
+// handleSignals waits for a signal, interrupts active spinners and tasks lists and re-raises the signal
+func handleSignals(c chan os.Signal) {
+	sig, ok := <-c
+	if !ok {
+		return
+	}
+	for _, s := range activeSpinners() {
+		s.interrupt()
+	}
+	for _, ts := range activeTasks() {
+		ts.stop()
+	}
+	signals.Lock()
+	if signals.c == c {
+		signal.Stop(c)
+		signals.c = nil
+		signals.count = 0
+	}
+	signals.Unlock()
+	raise(sig)
+}
+
+// exitCode returns conventional exit code of process terminated by signal sig
+func exitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
@@ -0,0 +1,83 @@
+package spinner
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleSignals(t *testing.T) {
+	raised := make(chan os.Signal, 1)
+	defer func(f func(os.Signal)) { raise = f }(raise)
+	raise = func(sig os.Signal) { raised <- sig }
+
+	s, err := New(Interval(time.Second), HandleSignals())
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.Start()
+	signals.Lock()
+	c := signals.c
+	signals.Unlock()
+	if c == nil {
+		t.Errorf("Expected signal handler to be installed")
+		return
+	}
+	c <- syscall.SIGTERM
+	select {
+	case sig := <-raised:
+		if sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM to be re-raised, given: %v", sig)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Expected signal to be re-raised")
+		return
+	}
+	if s.Active() {
+		t.Errorf("Expected spinner to be inactive")
+	}
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if !strings.Contains(output, "✖ Interrupted\n") || !strings.HasSuffix(output, "\x1b[?25h") {
+		t.Errorf("Unexpected output: %q", output)
+	}
+	signals.Lock()
+	defer signals.Unlock()
+	if signals.c != nil || signals.count != 0 {
+		t.Errorf("Expected signal handler to be uninstalled")
+	}
+}
+
+func TestRestoreOnExit(t *testing.T) {
+	first := RestoreOnExit()
+	second := RestoreOnExit()
+	first()
+	first()
+	signals.Lock()
+	installed := signals.c != nil
+	signals.Unlock()
+	if !installed {
+		t.Errorf("Expected signal handler to stay installed while in use")
+	}
+	second()
+	signals.Lock()
+	defer signals.Unlock()
+	if signals.c != nil || signals.count != 0 {
+		t.Errorf("Expected signal handler to be uninstalled")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if c := exitCode(syscall.SIGINT); c != 130 {
+		t.Errorf("exitCode(SIGINT) = %v, want 130", c)
+	}
+	if c := exitCode(os.Kill); c != 137 {
+		t.Errorf("exitCode(SIGKILL) = %v, want 137", c)
+	}
+}
@@ -2,12 +2,16 @@
 package spinner
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/mattn/go-colorable"
 	"github.com/mattn/go-runewidth"
 
 	"github.com/alecrabbit/go-cli-spinner/auxiliary"
@@ -26,41 +30,78 @@ type Spinner struct {
 	messageSettings    *elementSettings         //
 	progressSettings   *elementSettings         //
 	l                  *sync.RWMutex            // lock
+	lc                 sync.Mutex               // lifecycle lock, serializes Start() and Stop()
 	active             bool                     // flag, spinner is active
+	paused             bool                     // flag, spinner is paused
 	colorLevel         color.Level              // holds color level
-	stop               chan bool                // channel to stop the spinner
+	done               chan struct{}            // closed to stop the render goroutine
+	finished           chan struct{}            // closed by the render goroutine on exit
 	outputFormat       string                   // output format string
-	currentFrame       string                   // current frame string to write to output
+	currentFrame       []byte                   // current frame to write to output, refers to buffer
+	lastFrame          []byte                   // copy of the last written frame, empty if output changed since
+	buffer             bytes.Buffer             // reused buffer of currentFrame
 	currentFrameWidth  int                      // width of currentFrame string
 	previousFrameWidth int                      // previous width of currentFrame string
-	interval           time.Duration            // interval between spinner refreshes
+	currentFrameHeight int                      // number of rows occupied by currentFrame
+	interval           time.Duration            // interval between char updates
+	frameInterval      time.Duration            // min interval between redraws, set by MaxFPS()
+	throttle           time.Duration            // current interval between redraws, grows if writer is slow
+	redraw             chan struct{}            // requests prompt redraw
 	finalMessage       string                   // spinner final message, displayed by calling Stop()
 	reversed           bool                     // flag, spin in the opposite direction
 	hideCursor         bool                     // flag, hide cursor
 	prefix             string                   // spinner prefix
 	prefixWidth        int                      // width of prefix string
 	Writer             io.Writer                //
+	terminal           Terminal                 // cursor control sequences
+	Reader             io.Reader                // input for Prompt() and Confirm()
+	input              *bufio.Reader            // buffered Reader
+	inputSource        io.Reader                // Reader wrapped by input
+	pl                 sync.Mutex               // prompt lock
 	maxMessageWidth    int                      //
 	messageEllipsis    string                   //
+	messageTruncation  int                      // message truncation mode
+	marqueeStep        int                      // ticks per one cell shift of message marquee, 0 - marquee disabled
+	marqueePause       int                      // ticks to pause at each end of message marquee
+	queue              *messageQueue            // delays messages to show each for min duration, nil - disabled
+	lastMessage        string                   // last message set, before truncation
 	palette            *palette                 //
+	handlers           []EventHandler           // event handlers
+	startedAt          time.Time                // time of Start() call
+	successSymbol      string                   // symbol printed by Succeed()
+	failureSymbol      string                   // symbol printed by Fail()
+	jsonOutput         bool                     // flag, write events as JSON lines instead of frames
+	disabled           bool                     // flag, frames are not written, final messages are
+	handleSignals      bool                     // flag, install signal handler on Start()
+	releaseSignals     func()                   // releases signal handler installed by Start()
+	child              *child                   // child state, set for child spinners
+	children           []*Spinner               // child spinners rendered under the spinner
+	rowWidths          []int                    // widths of rows of previous frame of spinner with children
 }
 
 // New provides a pointer to an instance of Spinner
 func New(options ...Option) (*Spinner, error) {
 	charSet := CharSets[Snake2]
+	t := selectTerminal(runtime.GOOS, os.Getenv("TERM"), newConsole())
 	s := Spinner{
 		interval:        charSet.interval,
+		frameInterval:   time.Second / defaultMaxFPS,
+		redraw:          make(chan struct{}, 1),
 		palette:         charSet.palette,
 		l:               &sync.RWMutex{},
 		colorLevel:      color.TColor256,
-		outputFormat:    "%s%s%s%s",
-		stop:            make(chan bool),
+		outputFormat:    defaultOutputFormat,
 		finalMessage:    "",
 		hideCursor:      true,
-		Writer:          colorable.NewColorableStderr(),
+		Writer:          newWriter(t),
+		terminal:        t,
+		Reader:          os.Stdin,
 		elementsOrder:   []int{Char, Progress, Message},
 		maxMessageWidth: 50,
 		messageEllipsis: "…",
+		successSymbol:   "✔",
+		failureSymbol:   "✖",
+		jsonOutput:      os.Getenv(OutputEnv) == OutputJSON,
 	}
 	// Default settings for spinner elements
 	s.charSettings = &elementSettings{
@@ -91,21 +132,28 @@ func New(options ...Option) (*Spinner, error) {
 			return nil, err
 		}
 	}
-	// Process s.palette values // TODO: maybe a method is needed here
-	s.charSettings.colorizingSet = (*s.palette)[Char][s.colorLevel]
-	s.messageSettings.colorizingSet = (*s.palette)[Message][s.colorLevel]
-	s.progressSettings.colorizingSet = (*s.palette)[Progress][s.colorLevel]
-
-	// for _, entry := range s.elementsSettings {
-	// 	if color.Prototypes[entry.colorizingSet].Level > s.colorLevel {
-	// 		entry.colorizingSet = color.CNoColor
-	// 	}
-	// }
+	if s.jsonOutput {
+		s.handlers = append(s.handlers, s.writeJSON)
+	}
+	if s.disabled {
+		s.hideCursor = false
+	}
+	// Process s.palette values
+	for el, entry := range s.elementsSettings {
+		entry.colorizingSet = s.palette.colorizingSet(el, s.colorLevel)
+	}
 
 	// Create spinner elements
 	if err := s.createElements(); err != nil {
 		return nil, err
 	}
+	if s.char.height > 1 && !multiRow(s.terminal) {
+		// multi-row frames can't be redrawn
+		s.charSettings.charSet = CharSets[Snake2].chars
+		if err := s.createElements(); err != nil {
+			return nil, err
+		}
+	}
 	// Check interval
 	if err := checkInterval(s.interval); err != nil {
 		return nil, err
@@ -144,77 +192,386 @@ func (s *Spinner) Active() bool {
 	return s.active
 }
 
-// Start will start the spinner
+// Start will start the spinner, stopped spinner can be started again
 func (s *Spinner) Start() {
+	if s.child != nil {
+		return
+	}
+	s.lc.Lock()
 	s.l.Lock()
 	if s.active {
 		s.l.Unlock()
+		s.lc.Unlock()
 		return
 	}
-	if s.hideCursor {
+	if s.hideCursor && !s.jsonOutput {
 		// hide the cursor
-		s.write("\033[?25l")
+		s.write(s.terminal.HideCursor())
 	}
 
 	s.active = true
+	s.startedAt = time.Now()
+	s.currentFrameWidth = 0
+	s.lastFrame = s.lastFrame[:0]
+	s.done = make(chan struct{})
+	s.finished = make(chan struct{})
+	if s.handleSignals {
+		s.releaseSignals = installSignalHandler()
+	}
+	// the first frame is drawn without waiting for a tick
+	s.updateCurrentFrame()
+	s.requestRedraw()
+	go s.spin(s.done, s.finished)
 	s.l.Unlock()
-	go s.spin()
+	register(s)
+	// Note: handlers may call lifecycle methods, emit without lifecycle lock
+	s.lc.Unlock()
+	s.emit(Event{Type: Started})
 }
 
-func (s *Spinner) spin() {
+// spin renders frames until done is closed, closes finished on exit. Char advances every interval,
+// message and progress changes are redrawn promptly, frames are drawn not more often than throttle allows
+func (s *Spinner) spin(done <-chan struct{}, finished chan<- struct{}) {
 	ticker := time.NewTicker(s.interval)
+	defer close(finished)
+	defer ticker.Stop()
+	var (
+		next     time.Time        // earliest time of the next draw
+		deferred <-chan time.Time // fires when deferred frame can be drawn
+	)
 	for {
+		tick := false
 		select {
-		case <-s.stop:
+		case <-done:
 			return
 		case <-ticker.C:
+			tick = true
 			s.l.Lock()
 			s.updateCurrentFrame()
-			s.assembleCurrentFrame()
-			s.write(s.currentFrame)
 			s.l.Unlock()
+		case <-s.redraw:
+		case <-deferred:
+			deferred = nil
+		}
+		switch wait := time.Until(next); {
+		case deferred != nil:
+			// frame is already scheduled
+		case wait > 0:
+			deferred = time.After(wait)
+		default:
+			next = s.draw()
 		}
+		if tick {
+			s.emit(Event{Type: Tick})
+		}
+	}
+}
+
+// draw assembles and writes current frame, returns earliest time of the next draw
+func (s *Spinner) draw() time.Time {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.assembleCurrentFrame()
+	start := time.Now()
+	// unchanged frame is not written again
+	if !s.jsonOutput && !bytes.Equal(s.currentFrame, s.lastFrame) {
+		s.writeFrame()
 	}
+	s.throttle = throttle(s.throttle, s.frameInterval, time.Since(start))
+	return start.Add(s.throttle)
 }
 
+// dropRedraw drops redraw request made before the render goroutine is started, frame is already written
+func (s *Spinner) dropRedraw() {
+	select {
+	case <-s.redraw:
+	default:
+	}
+}
+
+// requestRedraw asks the render goroutine to redraw current frame promptly
+func (s *Spinner) requestRedraw() {
+	if s.child != nil {
+		s = s.child.parent
+	}
+	select {
+	case s.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// updateCurrentFrame advances animation of elements and children, called once per interval.
+// Frames are assembled without side effects, so redraws don't speed up animation
 func (s *Spinner) updateCurrentFrame() {
 	// Note: external lock
 	s.char.update()
+	s.message.update()
+	s.progress.update()
+	for _, c := range s.children {
+		c.l.Lock()
+		if c.active {
+			c.updateCurrentFrame()
+		}
+		c.l.Unlock()
+	}
 }
 
 func (s *Spinner) assembleCurrentFrame() {
 	// Note: external lock
 	s.previousFrameWidth = s.currentFrameWidth
-	first := s.elements[s.elementsOrder[0]]
-	second := s.elements[s.elementsOrder[1]]
-	third := s.elements[s.elementsOrder[2]]
-	f := fmt.Sprintf(
-		s.outputFormat,
-		s.prefix,
-		first.colorized(),
-		second.colorized(),
-		third.colorized(),
-	)
+	s.buffer.Reset()
+	if len(s.children) > 0 {
+		s.rollup()
+	}
+	switch {
+	case len(s.children) > 0 && multiRow(s.terminal):
+		s.assembleTreeFrame()
+	case s.char.height > 1:
+		s.assembleBlockFrame()
+	default:
+		s.writeLine(&s.buffer)
+		s.currentFrameWidth = s.lineWidth()
+		s.currentFrameHeight = 1
+		s.buffer.WriteString(s.terminal.ClearLine(s.previousFrameWidth - s.currentFrameWidth))
+		s.buffer.WriteString(s.terminal.MoveToColumn(s.currentFrameWidth))
+	}
+	s.currentFrame = s.buffer.Bytes()
+}
+
+// line returns single-line frame without control sequences
+func (s *Spinner) line() string {
+	// Note: external lock
+	var b bytes.Buffer
+	s.writeLine(&b)
+	return b.String()
+}
+
+// writeLine writes single-line frame without control sequences to b
+func (s *Spinner) writeLine(b *bytes.Buffer) {
+	// Note: external lock
+	if s.outputFormat != defaultOutputFormat {
+		_, _ = fmt.Fprintf(
+			b,
+			s.outputFormat,
+			s.prefix,
+			s.elements[s.elementsOrder[0]].colorized(),
+			s.elements[s.elementsOrder[1]].colorized(),
+			s.elements[s.elementsOrder[2]].colorized(),
+		)
+		return
+	}
+	b.WriteString(s.prefix)
+	for _, id := range s.elementsOrder {
+		s.elements[id].writeColorized(b)
+	}
+}
+
+// lineWidth returns width of single-line frame
+func (s *Spinner) lineWidth() int {
+	// Note: external lock
+	return s.prefixWidth + s.char.currentWidth + s.message.currentWidth + s.progress.currentWidth
+}
+
+// assembleBlockFrame assembles frame for multi-line char sets. Block frames are drawn
+// from the beginning of the line
+func (s *Spinner) assembleBlockFrame() {
+	// Note: external lock
+	rows := s.blockRows()
 	s.currentFrameWidth = s.prefixWidth + s.char.currentWidth + s.message.currentWidth + s.progress.currentWidth
-	s.currentFrame = f + eraseSequence(s.previousFrameWidth-s.currentFrameWidth) + moveBackSequence(s.currentFrameWidth)
+	s.currentFrameHeight = len(rows)
+	b := &s.buffer
+	b.WriteString("\r")
+	for i, r := range rows {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(r)
+		b.WriteString(s.terminal.ClearLine(s.previousFrameWidth - s.currentFrameWidth))
+	}
+	b.WriteString(s.terminal.Up(len(rows) - 1))
+	b.WriteString("\r")
 }
 
-// Stop stops the spinner
+// blockRows returns rows of frame for multi-line char sets, single-line elements are placed
+// on the middle row
+func (s *Spinner) blockRows() []string {
+	// Note: external lock
+	h := s.char.height
+	rows := make([]string, h)
+	middle := (h - 1) / 2
+	place := func(cell string, width int) {
+		for i := range rows {
+			if i == middle {
+				rows[i] += cell
+				continue
+			}
+			rows[i] += strings.Repeat(" ", width)
+		}
+	}
+	place(s.prefix, s.prefixWidth)
+	for _, id := range s.elementsOrder {
+		el := s.elements[id]
+		if el.height > 1 {
+			for i, r := range el.colorizedRows() {
+				rows[i] += r
+			}
+			continue
+		}
+		place(el.colorized(), el.currentWidth)
+	}
+	return rows
+}
+
+// Stop stops the spinner, can be called any number of times
 func (s *Spinner) Stop() {
+	s.finish(ResultDone, s.finalMessage, s.finalMessage)
+}
+
+// Succeed stops the spinner and writes success symbol followed by message m
+func (s *Spinner) Succeed(m string) {
+	s.finish(ResultSucceeded, resultMessage(s.successSymbol, m), m)
+}
+
+// Fail stops the spinner and writes failure symbol followed by message m
+func (s *Spinner) Fail(m string) {
+	s.finish(ResultFailed, resultMessage(s.failureSymbol, m), m)
+}
+
+// interrupt stops the spinner interrupted by signal
+func (s *Spinner) interrupt() {
+	s.finish(ResultInterrupted, resultMessage(s.failureSymbol, interruptedMessage), interruptedMessage)
+}
+
+// finish stops the spinner with result r and writes final message, m is passed to Stopped event.
+// Waits for the render goroutine to exit, does nothing if spinner is not active
+func (s *Spinner) finish(r Result, final, m string) {
+	if s.child != nil {
+		s.finishChild(r, m)
+		return
+	}
+	s.lc.Lock()
+	s.l.RLock()
+	active, paused := s.active, s.paused
+	s.l.RUnlock()
+	if !active {
+		s.lc.Unlock()
+		return
+	}
+	s.flushMessages(r != ResultInterrupted)
+	if !paused {
+		s.halt()
+	}
+
 	s.l.Lock()
-	defer s.l.Unlock()
-	if s.active {
-		s.erase()
-		s.active = false
-		s.stop <- true
-		if s.finalMessage != "" {
-			s.write(s.finalMessage)
+	s.erase()
+	s.active = false
+	s.paused = false
+	if !s.jsonOutput {
+		if final != "" {
+			s.write(final)
 		}
-		if s.hideCursor {
+		if len(s.children) > 0 {
+			s.write(s.summaries())
+		}
+		// children are not rendered after restart
+		s.children, s.rowWidths = nil, nil
+		if s.hideCursor && !paused {
 			// show the cursor
-			s.write("\033[?25h")
+			s.write(s.terminal.ShowCursor())
 		}
 	}
+	d := time.Since(s.startedAt)
+	release := s.releaseSignals
+	s.releaseSignals = nil
+	s.l.Unlock()
+	unregister(s)
+	if release != nil {
+		release()
+	}
+	s.lc.Unlock()
+	s.emit(Event{Type: Stopped, Result: r, Duration: d, Message: m})
+}
+
+// resultMessage returns final message composed of symbol and message m
+func resultMessage(symbol, m string) string {
+	if m == "" {
+		return symbol + "\n"
+	}
+	return symbol + " " + m + "\n"
+}
+
+// Pause erases spinner output, shows the cursor and stops animation, message and progress are kept
+func (s *Spinner) Pause() {
+	if s.child != nil {
+		return
+	}
+	s.lc.Lock()
+	s.l.RLock()
+	running := s.active && !s.paused
+	s.l.RUnlock()
+	if !running {
+		s.lc.Unlock()
+		return
+	}
+	s.halt()
+	s.l.Lock()
+	s.erase()
+	s.paused = true
+	if s.hideCursor && !s.jsonOutput {
+		// show the cursor
+		s.write(s.terminal.ShowCursor())
+	}
+	s.l.Unlock()
+	s.lc.Unlock()
+	s.emit(Event{Type: Paused})
+}
+
+// Resume redraws paused spinner and continues animation
+func (s *Spinner) Resume() {
+	if s.child != nil {
+		return
+	}
+	s.lc.Lock()
+	s.l.Lock()
+	if !s.active || !s.paused {
+		s.l.Unlock()
+		s.lc.Unlock()
+		return
+	}
+	s.paused = false
+	if !s.jsonOutput {
+		if s.hideCursor {
+			// hide the cursor
+			s.write(s.terminal.HideCursor())
+		}
+		// frame includes changes made while paused
+		s.assembleCurrentFrame()
+		s.writeFrame()
+	}
+	s.done = make(chan struct{})
+	s.finished = make(chan struct{})
+	s.dropRedraw()
+	go s.spin(s.done, s.finished)
+	s.l.Unlock()
+	s.lc.Unlock()
+	s.emit(Event{Type: Resumed})
+}
+
+// Paused returns true if spinner is currently paused
+func (s *Spinner) Paused() bool {
+	s.l.RLock()
+	defer s.l.RUnlock()
+	return s.paused
+}
+
+// halt stops the render goroutine and waits for it to exit
+func (s *Spinner) halt() {
+	// Note: lifecycle lock, the render goroutine takes s.l on every tick, wait for it without holding s.l
+	s.l.RLock()
+	done, finished := s.done, s.finished
+	s.l.RUnlock()
+	close(done)
+	<-finished
 }
 
 // Erase erases spinner output
@@ -227,30 +584,70 @@ func (s *Spinner) Erase() {
 // erase writes erasing sequence to output
 func (s *Spinner) erase() {
 	// Note: external lock
-	if s.active {
-		s.write(eraseSequence(s.currentFrameWidth))
+	if s.active && !s.paused && !s.jsonOutput && !s.disabled {
+		s.write(eraseBlockSequence(s.terminal, s.currentFrameWidth, s.currentFrameHeight))
+		s.lastFrame = s.lastFrame[:0]
 	}
 }
 
 // Current writes spinner current frame to output represented by spinner writer
 func (s *Spinner) Current() {
 	s.l.Lock()
-	s.write(s.currentFrame)
+	if !s.jsonOutput && !s.paused {
+		s.writeFrame()
+	}
 	s.l.Unlock()
 }
 
-// Message sets spinner message
+// Message sets spinner message, message is queued if MessageQueue() option is set
 func (s *Spinner) Message(m string) {
-	m = auxiliary.Truncate(m, s.maxMessageWidth, s.messageEllipsis)
 	s.l.Lock()
-	defer s.l.Unlock()
-	s.message.setCurrent(m)
+	if s.queue != nil && !s.queue.push(m, time.Now()) {
+		s.l.Unlock()
+		return
+	}
+	s.setMessage(m)
+	s.l.Unlock()
+	s.requestRedraw()
+	s.emit(Event{Type: MessageChanged, Message: m})
+}
 
+func (s *Spinner) setMessage(m string) {
+	// Note: external lock
+	s.lastMessage = m
+	if s.marqueeStep > 0 && s.maxMessageWidth > 0 {
+		if plain := auxiliary.StripANSI(m); s.frameWidth(plain) > s.maxMessageWidth {
+			s.message.setMarquee(newMarquee(plain, s.maxMessageWidth, s.marqueeStep, s.marqueePause))
+			return
+		}
+	}
+	s.message.setMarquee(nil)
+	switch s.messageTruncation {
+	case TruncateMiddle:
+		m = auxiliary.TruncateMiddle(m, s.maxMessageWidth, s.messageEllipsis)
+	default:
+		m = auxiliary.Truncate(m, s.maxMessageWidth, s.messageEllipsis)
+	}
+	s.message.setCurrent(m)
 }
 
-// Progress sets spinner progress value 0..1 → 0%..100%
+// Progress sets spinner progress value 0..1 → 0%..100%, progress of spinner with children is rolled up
+// from children values on every refresh
 func (s *Spinner) Progress(p float32) {
 	p = auxiliary.Bounds(p)
+	s.l.Lock()
+	s.setProgress(p)
+	if s.child != nil {
+		s.child.value = p
+	}
+	m := s.lastMessage
+	s.l.Unlock()
+	s.requestRedraw()
+	s.emit(Event{Type: ProgressChanged, Message: m, Progress: p})
+}
+
+func (s *Spinner) setProgress(p float32) {
+	// Note: external lock
 	var r string
 	switch {
 	case p > 0:
@@ -258,8 +655,6 @@ func (s *Spinner) Progress(p float32) {
 	default:
 		r = ""
 	}
-	s.l.Lock()
-	defer s.l.Unlock()
 	s.progress.setCurrent(r)
 }
 
@@ -275,3 +670,15 @@ func (s *Spinner) write(v string) {
 	// Suppressed returns
 	_, _ = fmt.Fprint(s.Writer, v)
 }
+
+// writeFrame writes current frame and keeps its copy as the last written frame
+func (s *Spinner) writeFrame() {
+	// Note: external lock
+	if s.disabled {
+		return
+	}
+
+	// Suppressed returns
+	_, _ = s.Writer.Write(s.currentFrame)
+	s.lastFrame = append(s.lastFrame[:0], s.currentFrame...)
+}
@@ -3,6 +3,7 @@ package spinner
 import (
 	"bytes"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -96,6 +97,216 @@ func TestDefaultRun(t *testing.T) {
 	}
 }
 
+// TestRestart verifies that stopped spinner can be started again
+func TestRestart(t *testing.T) {
+	s, err := New(FinalMessage("Done\n"))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.interval = 1 * time.Millisecond
+	for i := 0; i < 3; i++ {
+		s.Start()
+		s.Message("Message")
+		time.Sleep(5 * time.Millisecond)
+		s.Stop()
+		s.Stop()
+		if s.Active() {
+			t.Errorf("Expected spinner to be inactive after run #%v", i)
+		}
+	}
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if n := strings.Count(output, "Done\n"); n != 3 {
+		t.Errorf("Expected final message to be written 3 times, given: %v", n)
+	}
+	if !strings.HasSuffix(output, "Done\n\x1b[?25h") {
+		t.Errorf("Expected no frames after final message, given: %q", output)
+	}
+}
+
+// TestConcurrentStop verifies that concurrent Stop() calls write final message once
+func TestConcurrentStop(t *testing.T) {
+	s, err := New(FinalMessage("Done\n"))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.interval = 1 * time.Millisecond
+	s.Start()
+	time.Sleep(5 * time.Millisecond)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Message("Message")
+			s.Stop()
+		}()
+	}
+	wg.Wait()
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if n := strings.Count(output, "Done\n"); n != 1 {
+		t.Errorf("Expected final message to be written once, given: %v", n)
+	}
+}
+
+// TestPauseResume verifies that paused spinner doesn't write frames and keeps its state
+func TestPauseResume(t *testing.T) {
+	r := &eventRecorder{}
+	s, err := New(OnEvent(r.handle), ColorLevel(color.TNoColor))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.interval = 1 * time.Millisecond
+	s.Resume()
+	s.Pause()
+	if s.Paused() {
+		t.Errorf("Expected inactive spinner not to be paused")
+	}
+	s.Start()
+	s.Message("Message")
+	s.Progress(0.5)
+	time.Sleep(10 * time.Millisecond)
+	s.Pause()
+	s.Pause()
+	if !s.Paused() || !s.Active() {
+		t.Errorf("Expected spinner to be active and paused")
+	}
+	buffer.Lock()
+	paused := buffer.String()
+	buffer.Unlock()
+	if !strings.HasSuffix(paused, "\x1b[?25h") {
+		t.Errorf("Expected cursor to be shown on pause, given: %q", paused)
+	}
+	s.Erase()
+	s.Current()
+	time.Sleep(10 * time.Millisecond)
+	buffer.Lock()
+	if buffer.String() != paused {
+		t.Errorf("Expected no output while paused")
+	}
+	buffer.Unlock()
+	s.Resume()
+	time.Sleep(10 * time.Millisecond)
+	if s.Paused() {
+		t.Errorf("Expected spinner to be resumed")
+	}
+	s.Pause()
+	s.Stop()
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	resumed := output[len(paused):]
+	if !strings.HasPrefix(resumed, "\x1b[?25l") || !strings.Contains(resumed, "50% Message") {
+		t.Errorf("Expected spinner to be redrawn with message and progress, given: %q", resumed)
+	}
+	want := []EventType{Started, MessageChanged, ProgressChanged, Paused, Resumed, Paused, Stopped}
+	if got := r.types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+// TestPromptDraw verifies that frame is drawn on Start() and Resume() without waiting for a tick
+func TestPromptDraw(t *testing.T) {
+	s, err := New(Interval(time.Second), ColorLevel(color.TNoColor))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.Message("Started")
+	s.Start()
+	defer s.Stop()
+	time.Sleep(10 * time.Millisecond)
+	buffer.Lock()
+	started := buffer.String()
+	buffer.Unlock()
+	if !strings.Contains(started, "Started") {
+		t.Errorf("Expected message set before Start() to be drawn, given: %q", started)
+	}
+	s.Pause()
+	s.Message("Resumed")
+	s.Resume()
+	buffer.Lock()
+	resumed := buffer.String()[len(started):]
+	buffer.Unlock()
+	if !strings.Contains(resumed, "Resumed") {
+		t.Errorf("Expected message set while paused to be drawn on Resume(), given: %q", resumed)
+	}
+}
+
+// TestBlockRun verifies that multi-line frames are drawn and erased row by row
+func TestBlockRun(t *testing.T) {
+	s, err := New(Variant(Square3x3), ColorLevel(color.TNoColor))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	buffer := &syncBuffer{}
+	s.Writer = buffer
+	s.Message("Message")
+	s.l.Lock()
+	s.updateCurrentFrame()
+	s.assembleCurrentFrame()
+	frame := string(s.currentFrame)
+	s.l.Unlock()
+	rows := strings.Split(strings.TrimSuffix(frame, "\x1b[2A\r"), "\n")
+	if len(rows) != 3 {
+		t.Errorf("Expected 3 rows, given: %v", replaceEscapes(frame))
+		return
+	}
+	if rows[1] != "□□□ Message " {
+		t.Errorf("Unexpected middle row: %q", rows[1])
+	}
+	if rows[0] != "\r□■□         " {
+		t.Errorf("Unexpected first row: %q", rows[0])
+	}
+	if s.currentFrameHeight != 3 {
+		t.Errorf("Expected frame height 3, given: %v", s.currentFrameHeight)
+	}
+}
+
+// TestColorWave verifies that each grapheme of message gets its own shifted style
+func TestColorWave(t *testing.T) {
+	s, err := New(
+		ElementPalette(Message, map[color.Level]int{color.TColor256: color.C256YellowWhite}),
+		ColorWave(Message, 1),
+	)
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	if defaultPalette[Message][color.TColor256] != color.CDark {
+		t.Errorf("Expected shared palette to be untouched")
+	}
+	s.Message("ab")
+	s.message.update()
+	want := "\x1b[38;5;227ma\x1b[0m\x1b[38;5;226mb\x1b[0m\x1b[38;5;226m \x1b[0m"
+	for i := 0; i < 2; i++ {
+		// phase advances on update only
+		if got := s.message.colorized(); got != want {
+			t.Errorf("colorized() = %v, want %v", replaceEscapes(got), replaceEscapes(want))
+		}
+	}
+	s.message.update()
+	want = "\x1b[38;5;228ma\x1b[0m\x1b[38;5;227mb\x1b[0m\x1b[38;5;226m \x1b[0m"
+	if got := s.message.colorized(); got != want {
+		t.Errorf("colorized() = %v, want %v", replaceEscapes(got), replaceEscapes(want))
+	}
+}
+
 // TestSets verifies that set can be used
 func TestSets(t *testing.T) {
 	for idx, sp := range color.Prototypes {
@@ -182,6 +393,86 @@ func TestNew(t *testing.T) {
 			args{MessageEllipsis("1234")},
 			true,
 		},
+		{
+			"Palette for unknown element",
+			args{ElementPalette(7, map[color.Level]int{color.TColor256: color.C256Rainbow})},
+			true,
+		},
+		{
+			"Palette with unknown color level",
+			args{ElementPalette(Message, map[color.Level]int{13: color.C256Rainbow})},
+			true,
+		},
+		{
+			"Palette with unknown colorizing set",
+			args{ElementPalette(Message, map[color.Level]int{color.TColor256: 1313})},
+			true,
+		},
+		{
+			"Color wave for unknown element",
+			args{ColorWave(7, 1)},
+			true,
+		},
+		{
+			"Color wave shift is zero",
+			args{ColorWave(Message, 0)},
+			true,
+		},
+		{
+			"Output writer is nil",
+			args{Output(nil)},
+			true,
+		},
+		{
+			"Event handler is nil",
+			args{OnEvent(nil)},
+			true,
+		},
+		{
+			"Unknown message truncation mode",
+			args{MessageTruncation(5)},
+			true,
+		},
+		{
+			"Marquee step is zero",
+			args{MessageMarquee(0, 1)},
+			true,
+		},
+		{
+			"Marquee pause is negative",
+			args{MessageMarquee(1, -1)},
+			true,
+		},
+		{
+			"Max fps",
+			args{MaxFPS(30)},
+			false,
+		},
+		{
+			"Max fps is zero",
+			args{MaxFPS(0)},
+			true,
+		},
+		{
+			"Max fps is too big",
+			args{MaxFPS(1001)},
+			true,
+		},
+		{
+			"Message queue",
+			args{MessageQueue(300*time.Millisecond, QueueCoalesce|QueueFlush)},
+			false,
+		},
+		{
+			"Message queue min is zero",
+			args{MessageQueue(0, 0)},
+			true,
+		},
+		{
+			"Message queue unknown policy",
+			args{MessageQueue(time.Second, 4)},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -198,6 +489,71 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// countingWriter counts writes
+type countingWriter struct {
+	sync.Mutex
+	writes int
+}
+
+// Write ...
+func (w *countingWriter) Write(data []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	w.writes++
+	return len(data), nil
+}
+
+func (w *countingWriter) count() int {
+	w.Lock()
+	defer w.Unlock()
+	return w.writes
+}
+
+func TestUnchangedFrameIsNotWritten(t *testing.T) {
+	w := &countingWriter{}
+	s, err := New(
+		CharSet([]string{"*"}),
+		ColorLevel(color.TNoColor),
+		HideCursor(false),
+		Interval(20*time.Millisecond),
+		Output(w),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Message("Message")
+	s.Start()
+	defer s.Stop()
+	time.Sleep(150 * time.Millisecond)
+	if n := w.count(); n != 1 {
+		t.Errorf("Expected 1 write of unchanged frame, given: %v", n)
+	}
+	s.Message("Changed")
+	time.Sleep(150 * time.Millisecond)
+	if n := w.count(); n != 2 {
+		t.Errorf("Expected 2 writes after message change, given: %v", n)
+	}
+}
+
+func TestPromptRedraw(t *testing.T) {
+	buffer := &syncBuffer{}
+	s, err := New(Interval(time.Second), Output(buffer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	defer s.Stop()
+	time.Sleep(20 * time.Millisecond)
+	s.Message("Changed")
+	time.Sleep(50 * time.Millisecond)
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if !strings.Contains(output, "Changed") {
+		t.Errorf("Expected message to be redrawn before the next tick, given: %v", replaceEscapes(output))
+	}
+}
+
 /*
 Benchmarks
 */
@@ -232,3 +588,64 @@ Benchmarks
 // 	result = d
 // 	// fmt.Printf("Two %s", result)
 // }
+
+func BenchmarkAssembleCurrentFrame(b *testing.B) {
+	benchmarks := []struct {
+		name    string
+		options []Option
+	}{
+		{"default", nil},
+		{"no color", []Option{ColorLevel(color.TNoColor)}},
+		{"single frame", []Option{CharSet([]string{"*"}), ColorLevel(color.TNoColor)}},
+		{"char format", []Option{Format("-%s-")}},
+		{"color wave", []Option{ColorWave(Message, 2)}},
+		{"multi-line", []Option{Variant(Square3x3)}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			s, err := New(bm.options...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			s.Message("Message")
+			s.Progress(0.5)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				s.updateCurrentFrame()
+				s.assembleCurrentFrame()
+			}
+		})
+	}
+}
+
+// TestAssembleCurrentFrameNoSideEffects verifies that redraws don't advance animation
+func TestAssembleCurrentFrameNoSideEffects(t *testing.T) {
+	s, err := New(
+		ColorLevel(color.TColor256),
+		ElementPalette(Char, map[color.Level]int{color.TColor256: color.C256Rainbow}),
+		ColorWave(Message, 1),
+	)
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	s.Message("Message")
+	s.Child("child")
+	assemble := func() string {
+		s.assembleCurrentFrame()
+		return string(s.currentFrame)
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	first := assemble()
+	for i := 0; i < 3; i++ {
+		if f := assemble(); f != first {
+			t.Errorf("Expected the same frame, given: %v, want %v", replaceEscapes(f), replaceEscapes(first))
+		}
+	}
+	s.updateCurrentFrame()
+	if f := assemble(); f == first {
+		t.Errorf("Expected frame to change after update: %v", replaceEscapes(f))
+	}
+}
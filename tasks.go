@@ -0,0 +1,408 @@
+package spinner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner/auxiliary"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// TaskState represents state of task
+type TaskState int
+
+// Task states
+const (
+	// TaskPending task is waiting to be run
+	TaskPending TaskState = iota
+	// TaskRunning task is running
+	TaskRunning
+	// TaskDone task is finished successfully
+	TaskDone
+	// TaskSkipped task is skipped
+	TaskSkipped
+	// TaskFailed task is failed
+	TaskFailed
+)
+
+var taskStateNames = map[TaskState]string{
+	TaskPending: "pending",
+	TaskRunning: "running",
+	TaskDone:    "done",
+	TaskSkipped: "skipped",
+	TaskFailed:  "failed",
+}
+
+// String returns task state name
+func (st TaskState) String() string {
+	if n, ok := taskStateNames[st]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// taskIndent is indentation of subtasks
+const taskIndent = "  "
+
+// taskSymbols contains symbols of task states, running tasks are animated
+var taskSymbols = map[TaskState]string{
+	TaskPending: "○",
+	TaskSkipped: "↓",
+}
+
+// taskStyles contains styles of task state symbols for TColor16 and higher levels
+var taskStyles = map[TaskState]string{
+	TaskPending: "\x1b[2m%s\x1b[0m",
+	TaskDone:    "\x1b[32m%s\x1b[0m",
+	TaskSkipped: "\x1b[33m%s\x1b[0m",
+	TaskFailed:  "\x1b[31m%s\x1b[0m",
+}
+
+// errSkip is returned by Task.Skip()
+var errSkip = errors.New("spinner: task skipped")
+
+// TaskFunc represents task body, t can be used to add subtasks, set message or skip the task
+type TaskFunc func(t *Task) error
+
+// Task represents a named step of Tasks list
+type Task struct {
+	Concurrency int // max number of subtasks running at once, default 1
+
+	title    string   //
+	fn       TaskFunc //
+	list     *Tasks   //
+	state    TaskState
+	message  string
+	err      error
+	children []*Task
+}
+
+// Tasks represents list of tasks, each task is shown on its own line
+type Tasks struct {
+	Concurrency int // max number of tasks running at once, default 1
+
+	s              *Spinner      // provides char set, colors, symbols, writer and output modes
+	l              sync.Mutex    // lock
+	lc             sync.Mutex    // lifecycle lock, serializes start() and stop()
+	tasks          []*Task       //
+	widths         []int         // widths of rows of previous frame
+	height         int           // number of rows of previous frame
+	running        string        // symbol of running tasks in current frame
+	active         bool          // flag, list is rendered
+	done           chan struct{} // closed to stop render goroutine, nil if frames are not rendered
+	finished       chan struct{} // closed by render goroutine on exit
+	releaseSignals func()        // releases signal handler installed by start()
+}
+
+// NewTasks returns tasks list, options are the same as for spinner, multi-line char sets are not supported
+func NewTasks(options ...Option) (*Tasks, error) {
+	s, err := New(options...)
+	if err != nil {
+		return nil, err
+	}
+	if s.char.height > 1 {
+		return nil, fmt.Errorf("spinner: multi-line char set can't be used for tasks")
+	}
+	s.char.update()
+	return &Tasks{s: s, Concurrency: 1}, nil
+}
+
+// Add adds task
+func (ts *Tasks) Add(title string, fn TaskFunc) *Task {
+	ts.l.Lock()
+	defer ts.l.Unlock()
+	t := &Task{title: title, fn: fn, list: ts, Concurrency: 1}
+	ts.tasks = append(ts.tasks, t)
+	return t
+}
+
+// Add adds subtask, subtasks are run after task body. Can be called from task body
+func (t *Task) Add(title string, fn TaskFunc) *Task {
+	t.list.l.Lock()
+	defer t.list.l.Unlock()
+	c := &Task{title: title, fn: fn, list: t.list, Concurrency: 1}
+	t.children = append(t.children, c)
+	return c
+}
+
+// Message sets task message shown after the title
+func (t *Task) Message(m string) {
+	t.list.l.Lock()
+	defer t.list.l.Unlock()
+	t.message = auxiliary.Truncate(m, t.list.s.maxMessageWidth, t.list.s.messageEllipsis)
+}
+
+// Skip marks task as skipped with reason, should be returned from task body
+//
+//	return t.Skip("up to date")
+func (t *Task) Skip(reason string) error {
+	t.Message(reason)
+	return errSkip
+}
+
+// State returns task state
+func (t *Task) State() TaskState {
+	t.list.l.Lock()
+	defer t.list.l.Unlock()
+	return t.state
+}
+
+// Err returns error of failed task
+func (t *Task) Err() error {
+	t.list.l.Lock()
+	defer t.list.l.Unlock()
+	return t.err
+}
+
+// Run runs tasks and renders the list until all tasks are finished, returns the first error.
+// Tasks are not started after a failure. Disabled list or list on terminal which can't move cursor up
+// writes the final list only, JSON output mode writes task state changes as lines of JSON
+func (ts *Tasks) Run() error {
+	tasks := ts.start()
+	err := runTasks(tasks, ts.Concurrency)
+	ts.stop()
+	return err
+}
+
+// start starts rendering of the list, returns tasks to run
+func (ts *Tasks) start() []*Task {
+	ts.lc.Lock()
+	defer ts.lc.Unlock()
+	if ts.s.handleSignals {
+		ts.releaseSignals = installSignalHandler()
+	}
+	ts.l.Lock()
+	defer ts.l.Unlock()
+	ts.active = true
+	if !ts.s.disabled && !ts.s.jsonOutput && multiRow(ts.s.terminal) {
+		if ts.s.hideCursor {
+			// hide the cursor
+			ts.s.write(ts.s.terminal.HideCursor())
+		}
+		ts.done = make(chan struct{})
+		ts.finished = make(chan struct{})
+		go ts.render(ts.done, ts.finished)
+	}
+	registerTasks(ts)
+	return ts.tasks
+}
+
+// stop stops rendering, writes the final list and shows the cursor. Used by Run(), StopAll(),
+// Recover() and signal handler, does nothing if the list is not rendered
+func (ts *Tasks) stop() {
+	ts.lc.Lock()
+	defer ts.lc.Unlock()
+	ts.l.Lock()
+	active, done, finished := ts.active, ts.done, ts.finished
+	ts.active, ts.done, ts.finished = false, nil, nil
+	ts.l.Unlock()
+	if !active {
+		return
+	}
+	unregisterTasks(ts)
+	if done != nil {
+		close(done)
+		<-finished
+	}
+	ts.l.Lock()
+	switch {
+	case ts.s.jsonOutput:
+	case ts.s.disabled || !multiRow(ts.s.terminal):
+		ts.running = ts.s.char.colorized()
+		if rows := ts.rows(); len(rows) > 0 {
+			ts.s.write(strings.Join(rows, "\n") + "\n")
+		}
+	default:
+		if len(ts.tasks) > 0 {
+			ts.s.write(ts.frame() + ts.s.terminal.Down(ts.height-1) + "\n")
+		}
+		if ts.s.hideCursor {
+			// show the cursor
+			ts.s.write(ts.s.terminal.ShowCursor())
+		}
+	}
+	ts.l.Unlock()
+	if ts.releaseSignals != nil {
+		ts.releaseSignals()
+		ts.releaseSignals = nil
+	}
+}
+
+// render redraws tasks list until done is closed
+func (ts *Tasks) render(done <-chan struct{}, finished chan<- struct{}) {
+	ticker := time.NewTicker(ts.s.interval)
+	defer close(finished)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			ts.l.Lock()
+			ts.s.char.update()
+			ts.s.write(ts.frame())
+			ts.l.Unlock()
+		}
+	}
+}
+
+// runTasks runs tasks with at most n of them at once, stops starting tasks after a failure
+func runTasks(tasks []*Task, n int) error {
+	if n < 1 {
+		n = 1
+	}
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		first error
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return first != nil
+	}
+	sem := make(chan struct{}, n)
+	for _, t := range tasks {
+		sem <- struct{}{}
+		if failed() {
+			break
+		}
+		wg.Add(1)
+		go func(t *Task) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := t.run(); err != nil {
+				mu.Lock()
+				if first == nil {
+					first = err
+				}
+				mu.Unlock()
+			}
+		}(t)
+	}
+	wg.Wait()
+	return first
+}
+
+// run runs task body and subtasks
+func (t *Task) run() error {
+	t.setState(TaskRunning, nil)
+	var err error
+	if t.fn != nil {
+		err = t.fn(t)
+	}
+	if err == errSkip {
+		t.setState(TaskSkipped, nil)
+		return nil
+	}
+	if err == nil {
+		t.list.l.Lock()
+		children := t.children
+		t.list.l.Unlock()
+		err = runTasks(children, t.Concurrency)
+	}
+	if err != nil {
+		t.setState(TaskFailed, err)
+		return err
+	}
+	t.setState(TaskDone, nil)
+	return nil
+}
+
+func (t *Task) setState(state TaskState, err error) {
+	t.list.l.Lock()
+	defer t.list.l.Unlock()
+	t.state = state
+	t.err = err
+	if t.list.s.jsonOutput && t.list.active {
+		t.list.writeJSON(t)
+	}
+}
+
+// frame returns tasks list frame, cursor returns to the first row
+func (ts *Tasks) frame() string {
+	// Note: external lock
+	ts.running = ts.s.char.colorized()
+	rows := ts.rows()
+	widths := make([]int, len(rows))
+	for i, r := range rows {
+		widths[i] = ts.s.frameWidth(r)
+	}
+	f := rowsSequence(ts.s.terminal, rows, widths, ts.widths)
+	ts.widths = widths
+	ts.height = len(rows)
+	return f
+}
+
+// rows returns rows of tasks and subtasks
+func (ts *Tasks) rows() []string {
+	// Note: external lock
+	var rows []string
+	for _, t := range ts.tasks {
+		rows = t.rows(rows, "")
+	}
+	return rows
+}
+
+// writeJSON writes state of task t as a line of JSON to output
+func (ts *Tasks) writeJSON(t *Task) {
+	// Note: external lock
+	e := jsonEvent{Time: time.Now(), Event: "task", Message: t.title, Result: t.state.String()}
+	switch {
+	case t.state == TaskFailed && t.err != nil:
+		e.Message += " " + t.err.Error()
+	case t.state == TaskSkipped && t.message != "":
+		e.Message += " " + t.message
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	ts.s.write(string(b) + "\n")
+}
+
+// rows appends rows of task and its subtasks to r
+func (t *Task) rows(r []string, indent string) []string {
+	// Note: external lock
+	row := indent + t.list.symbol(t.state) + t.title
+	switch {
+	case t.state == TaskFailed && t.err != nil:
+		row += " " + auxiliary.Truncate(t.err.Error(), t.list.s.maxMessageWidth, t.list.s.messageEllipsis)
+	case t.message != "" && t.state != TaskDone:
+		row += " " + t.message
+	}
+	r = append(r, row)
+	for _, c := range t.children {
+		r = c.rows(r, indent+taskIndent)
+	}
+	return r
+}
+
+// symbol returns colorized symbol of task state followed by spacer
+func (ts *Tasks) symbol(state TaskState) string {
+	// Note: external lock
+	if state == TaskRunning {
+		return ts.running
+	}
+	sym, ok := taskSymbols[state]
+	switch {
+	case ok:
+	case state == TaskDone:
+		sym = ts.s.successSymbol
+	default:
+		sym = ts.s.failureSymbol
+	}
+	pad := ts.s.char.currentWidth - ts.s.frameWidth(sym)
+	if pad < 1 {
+		pad = 1
+	}
+	sym += strings.Repeat(" ", pad)
+	if ts.s.colorLevel >= color.TColor16 {
+		return fmt.Sprintf(taskStyles[state], sym)
+	}
+	return sym
+}
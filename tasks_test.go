@@ -0,0 +1,213 @@
+package spinner
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner/auxiliary"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// lastFrameRows returns rows of the last frame written by tasks list
+func lastFrameRows(output string) []string {
+	output = auxiliary.StripANSI(strings.TrimSuffix(output, "\x1b[?25h"))
+	frames := strings.Split(output, "\r")
+	// final frame is followed by "\n", cursor returns to the first row before it
+	last := frames[len(frames)-2]
+	return strings.Split(last, "\n")
+}
+
+func TestTasksRun(t *testing.T) {
+	buffer := &syncBuffer{}
+	ts, err := NewTasks(Output(buffer), ColorLevel(color.TNoColor), Variant(Dots14))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	ts.Add("Prepare", nil)
+	build := ts.Add("Build", func(t *Task) error {
+		t.Add("Compile", func(t *Task) error {
+			t.Message("main.go")
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+		t.Add("Lint", func(t *Task) error { return t.Skip("disabled") })
+		return nil
+	})
+	deploy := ts.Add("Deploy", func(t *Task) error { return errors.New("no access") })
+	publish := ts.Add("Publish", nil)
+
+	err = ts.Run()
+	if err == nil || err.Error() != "no access" {
+		t.Errorf("Run() error = %v, want no access", err)
+	}
+	if build.State() != TaskDone || deploy.State() != TaskFailed || publish.State() != TaskPending {
+		t.Errorf("Unexpected states: %v %v %v", build.State(), deploy.State(), publish.State())
+	}
+	if deploy.Err() == nil {
+		t.Errorf("Expected failed task to hold error")
+	}
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	want := []string{
+		"✔ Prepare",
+		"✔ Build",
+		"  ✔ Compile",
+		"  ↓ Lint disabled",
+		"✖ Deploy no access",
+		"○ Publish",
+	}
+	rows := lastFrameRows(output)
+	if len(rows) != len(want) {
+		t.Errorf("Unexpected frame: %q", rows)
+		return
+	}
+	for i, w := range want {
+		if strings.TrimRight(rows[i], " ") != w {
+			t.Errorf("row #%v = %q, want %q", i, rows[i], w)
+		}
+	}
+}
+
+func TestTasksConcurrency(t *testing.T) {
+	ts, err := NewTasks(Output(&syncBuffer{}))
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	ts.Concurrency = 3
+	var (
+		mu      sync.Mutex
+		running int
+		max     int
+	)
+	for i := 0; i < 10; i++ {
+		ts.Add("Task", func(t *Task) error {
+			mu.Lock()
+			running++
+			if running > max {
+				max = running
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := ts.Run(); err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+	}
+	if max < 2 || max > 3 {
+		t.Errorf("Expected at most 3 tasks at once, given: %v", max)
+	}
+}
+
+func TestNewTasksMultiLine(t *testing.T) {
+	if _, err := NewTasks(Variant(Splash)); err == nil {
+		t.Errorf("Expected error for multi-line char set")
+	}
+}
+
+func TestTasksOutputModes(t *testing.T) {
+	tests := []struct {
+		name   string
+		option Option
+		want   string
+	}{
+		{"disabled", Disable(), "✔ Build\n✖ Deploy no access\n"},
+		{"json", JSONOutput(), `{"ts":"X","event":"task","message":"Build","result":"running"}` + "\n" +
+			`{"ts":"X","event":"task","message":"Build","result":"done"}` + "\n" +
+			`{"ts":"X","event":"task","message":"Deploy","result":"running"}` + "\n" +
+			`{"ts":"X","event":"task","message":"Deploy no access","result":"failed"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := &syncBuffer{}
+			ts, err := NewTasks(Output(buffer), ColorLevel(color.TNoColor), tt.option)
+			if err != nil {
+				t.Errorf("Unexpected error (%v)", err)
+				return
+			}
+			ts.Add("Build", nil)
+			ts.Add("Deploy", func(t *Task) error { return errors.New("no access") })
+			if err := ts.Run(); err == nil {
+				t.Errorf("Expected error")
+			}
+			buffer.Lock()
+			output := buffer.String()
+			buffer.Unlock()
+			if strings.Contains(output, "\x1b[") {
+				t.Errorf("Unexpected escape sequences: %q", output)
+			}
+			// timestamps vary
+			output = regexp.MustCompile(`"ts":"[^"]*"`).ReplaceAllString(output, `"ts":"X"`)
+			if output != tt.want {
+				t.Errorf("Unexpected output:\n%q\nwant:\n%q", output, tt.want)
+			}
+		})
+	}
+}
+
+func TestTasksHandleSignals(t *testing.T) {
+	raised := make(chan os.Signal, 1)
+	defer func(f func(os.Signal)) { raise = f }(raise)
+	raise = func(sig os.Signal) { raised <- sig }
+
+	buffer := &syncBuffer{}
+	ts, err := NewTasks(Output(buffer), Interval(20*time.Millisecond), HandleSignals())
+	if err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+		return
+	}
+	release := make(chan struct{})
+	started := make(chan struct{})
+	ts.Add("Build", func(t *Task) error {
+		close(started)
+		<-release
+		return nil
+	})
+	result := make(chan error)
+	go func() { result <- ts.Run() }()
+	<-started
+	signals.Lock()
+	c := signals.c
+	signals.Unlock()
+	if c == nil {
+		t.Errorf("Expected signal handler to be installed")
+		close(release)
+		return
+	}
+	c <- syscall.SIGTERM
+	select {
+	case <-raised:
+	case <-time.After(time.Second):
+		t.Errorf("Expected signal to be re-raised")
+	}
+	if n := len(activeTasks()); n != 0 {
+		t.Errorf("Expected no active tasks lists, given: %v", n)
+	}
+	buffer.Lock()
+	output := buffer.String()
+	buffer.Unlock()
+	if !strings.HasSuffix(output, "\x1b[?25h") {
+		t.Errorf("Expected cursor to be shown, output: %q", output)
+	}
+	close(release)
+	if err := <-result; err != nil {
+		t.Errorf("Unexpected error (%v)", err)
+	}
+	buffer.Lock()
+	defer buffer.Unlock()
+	if buffer.String() != output {
+		t.Errorf("Expected nothing to be written after stop")
+	}
+}
@@ -0,0 +1,146 @@
+package spinner
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-colorable"
+
+	"github.com/alecrabbit/go-cli-spinner/auxiliary"
+)
+
+// Terminal represents strategy of cursor control sequences. Frames are written starting at cursor
+// position, after each frame cursor is moved back to the frame start
+type Terminal interface {
+	// HideCursor returns sequence hiding the cursor
+	HideCursor() string
+	// ShowCursor returns sequence showing the cursor
+	ShowCursor() string
+	// ClearLine returns sequence erasing w cells from cursor position, cursor is not moved
+	ClearLine(w int) string
+	// MoveToColumn returns sequence moving cursor w columns back, to the column of the frame start
+	MoveToColumn(w int) string
+	// Up returns sequence moving cursor n rows up
+	Up(n int) string
+	// Down returns sequence moving cursor n rows down
+	Down(n int) string
+}
+
+// ANSITerminal uses ANSI/xterm sequences, default terminal
+type ANSITerminal struct{}
+
+// HideCursor ...
+func (ANSITerminal) HideCursor() string { return "\x1b[?25l" }
+
+// ShowCursor ...
+func (ANSITerminal) ShowCursor() string { return "\x1b[?25h" }
+
+// ClearLine ...
+func (ANSITerminal) ClearLine(w int) string { return eraseSequence(w) }
+
+// MoveToColumn ...
+func (ANSITerminal) MoveToColumn(w int) string { return moveBackSequence(w) }
+
+// Up ...
+func (ANSITerminal) Up(n int) string { return moveUpSequence(n) }
+
+// Down ...
+func (ANSITerminal) Down(n int) string { return moveDownSequence(n) }
+
+// CarriageReturnTerminal uses carriage return and spaces only, used if TERM=dumb.
+// Frames are drawn from the beginning of the line. Cursor can't be moved up, so frames are single-line:
+// multi-line char sets are replaced, children and tasks lists are written when finished
+type CarriageReturnTerminal struct{}
+
+// HideCursor ...
+func (CarriageReturnTerminal) HideCursor() string { return "" }
+
+// ShowCursor ...
+func (CarriageReturnTerminal) ShowCursor() string { return "" }
+
+// ClearLine overwrites w cells with spaces, cursor returns to the beginning of the line
+func (CarriageReturnTerminal) ClearLine(w int) string {
+	if w < 1 {
+		return ""
+	}
+	return strings.Repeat(" ", w) + "\r"
+}
+
+// MoveToColumn returns cursor to the beginning of the line
+func (CarriageReturnTerminal) MoveToColumn(w int) string { return "\r" }
+
+// Up ...
+func (CarriageReturnTerminal) Up(n int) string { return "" }
+
+// Down ...
+func (CarriageReturnTerminal) Down(n int) string { return "" }
+
+// NoOpTerminal writes no control sequences, frames are written one after another
+type NoOpTerminal struct{}
+
+// HideCursor ...
+func (NoOpTerminal) HideCursor() string { return "" }
+
+// ShowCursor ...
+func (NoOpTerminal) ShowCursor() string { return "" }
+
+// ClearLine ...
+func (NoOpTerminal) ClearLine(w int) string { return "" }
+
+// MoveToColumn ...
+func (NoOpTerminal) MoveToColumn(w int) string { return "" }
+
+// Up ...
+func (NoOpTerminal) Up(n int) string { return "" }
+
+// Down ...
+func (NoOpTerminal) Down(n int) string { return "" }
+
+// legacyTerminal uses sequences translated by go-colorable on legacy Windows console. Erase sequence ECH
+// is not translated, so trailing cells are overwritten with spaces and cursor returns by carriage return,
+// frames are drawn from the beginning of the line. Cursor visibility and moves up and down are translated
+type legacyTerminal struct {
+	ANSITerminal
+}
+
+// ClearLine overwrites w cells with spaces, cursor returns to the beginning of the line
+func (legacyTerminal) ClearLine(w int) string {
+	return CarriageReturnTerminal{}.ClearLine(w)
+}
+
+// MoveToColumn returns cursor to the beginning of the line
+func (legacyTerminal) MoveToColumn(w int) string { return "\r" }
+
+// multiRow returns true if terminal t can move cursor up to redraw multi-row frames
+func multiRow(t Terminal) bool {
+	return t.Up(1) != ""
+}
+
+// console represents platform console
+type console interface {
+	// enableVirtualTerminal enables processing of ANSI escape sequences, returns false if not supported
+	enableVirtualTerminal() bool
+}
+
+// dumbTerminal is value of TERM environment variable of terminals without cursor control
+const dumbTerminal = "dumb"
+
+// selectTerminal returns terminal for console c on goos, term is value of TERM environment variable
+func selectTerminal(goos, term string, c console) Terminal {
+	switch {
+	case term == dumbTerminal:
+		return CarriageReturnTerminal{}
+	case goos == auxiliary.WINDOWS && !c.enableVirtualTerminal():
+		return legacyTerminal{}
+	}
+	return ANSITerminal{}
+}
+
+// newWriter returns writer for terminal t, go-colorable translates sequences on legacy console
+func newWriter(t Terminal) io.Writer {
+	if _, ok := t.(legacyTerminal); ok {
+		return colorable.NewColorableStderr()
+	}
+	return os.Stderr
+}
@@ -0,0 +1,167 @@
+package spinner
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecrabbit/go-cli-spinner/auxiliary"
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// fakeConsole ...
+type fakeConsole struct {
+	vt     bool // virtual terminal processing is available
+	called bool
+}
+
+func (c *fakeConsole) enableVirtualTerminal() bool {
+	c.called = true
+	return c.vt
+}
+
+func TestSelectTerminal(t *testing.T) {
+	tests := []struct {
+		name   string
+		goos   string
+		term   string
+		vt     bool
+		want   Terminal
+		called bool
+	}{
+		{"linux", "linux", "xterm-256color", false, ANSITerminal{}, false},
+		{"darwin", "darwin", "", true, ANSITerminal{}, false},
+		{"linux dumb", "linux", "dumb", true, CarriageReturnTerminal{}, false},
+		{"windows vt", auxiliary.WINDOWS, "", true, ANSITerminal{}, true},
+		{"windows legacy", auxiliary.WINDOWS, "", false, legacyTerminal{}, true},
+		{"windows dumb", auxiliary.WINDOWS, "dumb", false, CarriageReturnTerminal{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeConsole{vt: tt.vt}
+			if got := selectTerminal(tt.goos, tt.term, c); got != tt.want {
+				t.Errorf("selectTerminal() = %T, want %T", got, tt.want)
+			}
+			if c.called != tt.called {
+				t.Errorf("enableVirtualTerminal() called = %v, want %v", c.called, tt.called)
+			}
+		})
+	}
+}
+
+func TestTerminals(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ansi hide cursor", ANSITerminal{}.HideCursor(), "\x1b[?25l"},
+		{"ansi show cursor", ANSITerminal{}.ShowCursor(), "\x1b[?25h"},
+		{"ansi clear line", ANSITerminal{}.ClearLine(3), "\x1b[3X"},
+		{"ansi move to column", ANSITerminal{}.MoveToColumn(3), "\x1b[3D"},
+		{"ansi up", ANSITerminal{}.Up(2), "\x1b[2A"},
+		{"ansi down", ANSITerminal{}.Down(2), "\x1b[2B"},
+		{"cr hide cursor", CarriageReturnTerminal{}.HideCursor(), ""},
+		{"cr clear line 0", CarriageReturnTerminal{}.ClearLine(0), ""},
+		{"cr clear line", CarriageReturnTerminal{}.ClearLine(3), "   \r"},
+		{"cr move to column", CarriageReturnTerminal{}.MoveToColumn(3), "\r"},
+		{"cr up", CarriageReturnTerminal{}.Up(2), ""},
+		{"noop clear line", NoOpTerminal{}.ClearLine(3), ""},
+		{"noop move to column", NoOpTerminal{}.MoveToColumn(3), ""},
+		{"legacy hide cursor", legacyTerminal{}.HideCursor(), "\x1b[?25l"},
+		{"legacy show cursor", legacyTerminal{}.ShowCursor(), "\x1b[?25h"},
+		{"legacy clear line 0", legacyTerminal{}.ClearLine(0), ""},
+		{"legacy clear line -1", legacyTerminal{}.ClearLine(-1), ""},
+		{"legacy clear line", legacyTerminal{}.ClearLine(3), "   \r"},
+		{"legacy move to column", legacyTerminal{}.MoveToColumn(3), "\r"},
+		{"legacy up", legacyTerminal{}.Up(2), "\x1b[2A"},
+		{"legacy down", legacyTerminal{}.Down(2), "\x1b[2B"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", replaceEscapes(tt.got), replaceEscapes(tt.want))
+			}
+		})
+	}
+}
+
+func TestTerminalRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		terminal Terminal
+		cursor   bool // cursor sequences are written
+	}{
+		{"legacy", legacyTerminal{}, true},
+		{"carriage return", CarriageReturnTerminal{}, false},
+		{"noop", NoOpTerminal{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &syncBuffer{}
+			s, err := New(Output(out), Interval(20*time.Millisecond), TerminalControl(tt.terminal))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.Message("Message")
+			s.Start()
+			time.Sleep(50 * time.Millisecond)
+			s.Message("M")
+			time.Sleep(50 * time.Millisecond)
+			s.Stop()
+			output := out.String()
+			if strings.Contains(output, "\x1b[?25") != tt.cursor {
+				t.Errorf("cursor sequences written = %v, want %v: %v", !tt.cursor, tt.cursor, replaceEscapes(output))
+			}
+			if strings.Contains(output, "X") {
+				t.Errorf("erase sequence written: %v", replaceEscapes(output))
+			}
+			if !strings.Contains(auxiliary.StripANSI(output), "M") {
+				t.Errorf("frame is not written: %v", replaceEscapes(output))
+			}
+		})
+	}
+}
+
+func TestTerminalControlNil(t *testing.T) {
+	if _, err := New(TerminalControl(nil)); err == nil {
+		t.Errorf("New() error = nil, want error")
+	}
+}
+
+func TestCarriageReturnTerminalSingleLine(t *testing.T) {
+	s, err := New(
+		Output(&syncBuffer{}), Variant(Splash), ColorLevel(color.TNoColor), TerminalControl(CarriageReturnTerminal{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.char.height != 1 {
+		t.Errorf("Expected single-line char set, given height: %v", s.char.height)
+	}
+	s.Child("compile").Progress(0.5)
+	s.l.Lock()
+	s.assembleCurrentFrame()
+	frame, height := string(s.currentFrame), s.currentFrameHeight
+	s.l.Unlock()
+	if height != 1 || strings.Contains(frame, "\n") || strings.Contains(frame, "compile") {
+		t.Errorf("Unexpected frame: %v", replaceEscapes(frame))
+	}
+	if !strings.Contains(frame, "50%") {
+		t.Errorf("Expected rolled up progress: %v", replaceEscapes(frame))
+	}
+
+	out := &syncBuffer{}
+	ts, err := NewTasks(Output(out), ColorLevel(color.TNoColor), TerminalControl(CarriageReturnTerminal{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.Add("Build", nil)
+	ts.Add("Test", nil)
+	if err := ts.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if output := out.String(); output != "✔ Build\n✔ Test\n" {
+		t.Errorf("Unexpected tasks output: %v", replaceEscapes(output))
+	}
+}
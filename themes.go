@@ -0,0 +1,213 @@
+package spinner
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+// ThemeSpec describes a theme, empty strings keep defaults
+type ThemeSpec struct {
+	Variant                 string                      // char set variant name, see VariantNames()
+	Palette                 map[int]map[color.Level]int // colorizing sets of elements for each color level
+	CharFormat              string                      // see Format()
+	MessageFormat           string                      //
+	ProgressFormat          string                      //
+	ProgressIndicatorFormat string                      //
+	Ellipsis                string                      // see MessageEllipsis()
+	SuccessSymbol           string                      //
+	FailureSymbol           string                      //
+}
+
+// monochrome returns palette entries using no colors at every level
+func monochrome() map[color.Level]int {
+	return map[color.Level]int{
+		color.TTrueColor: color.CNoColor,
+		color.TColor256:  color.CNoColor,
+		color.TColor16:   color.CNoColor,
+		color.TNoColor:   color.CNoColor,
+	}
+}
+
+var (
+	themesLock sync.RWMutex
+	themes     = map[string]ThemeSpec{
+		"minimal": {
+			Variant: "Dots13",
+			Palette: map[int]map[color.Level]int{
+				Char:     monochrome(),
+				Message:  monochrome(),
+				Progress: monochrome(),
+			},
+			Ellipsis:      "…",
+			SuccessSymbol: "✓",
+			FailureSymbol: "✗",
+		},
+		"npm": {
+			Variant: "Dots14",
+			Palette: map[int]map[color.Level]int{
+				Char: {
+					color.TTrueColor: color.CLightCyan,
+					color.TColor256:  color.CLightCyan,
+					color.TColor16:   color.CLightCyan,
+					color.TNoColor:   color.CNoColor,
+				},
+				Message: {
+					color.TTrueColor: color.CDark,
+					color.TColor256:  color.CDark,
+					color.TColor16:   color.CDark,
+					color.TNoColor:   color.CNoColor,
+				},
+				Progress: monochrome(),
+			},
+			ProgressIndicatorFormat: "%.0f%%",
+			Ellipsis:                "…",
+			SuccessSymbol:           "✔",
+			FailureSymbol:           "✖",
+		},
+		"dots-rainbow": {
+			Variant: "FlyingDots",
+			Palette: map[int]map[color.Level]int{
+				Char: {
+					color.TTrueColor: color.C256Rainbow,
+					color.TColor256:  color.C256Rainbow,
+					color.TColor16:   color.CLightCyan,
+					color.TNoColor:   color.CNoColor,
+				},
+				Message: {
+					color.TTrueColor: color.C256YellowWhite,
+					color.TColor256:  color.C256YellowWhite,
+					color.TColor16:   color.CDark,
+					color.TNoColor:   color.CNoColor,
+				},
+				Progress: {
+					color.TTrueColor: color.C256Rainbow,
+					color.TColor256:  color.C256Rainbow,
+					color.TColor16:   color.CLightCyan,
+					color.TNoColor:   color.CNoColor,
+				},
+			},
+			ProgressIndicatorFormat: "%.1f%%",
+			Ellipsis:                "…",
+			SuccessSymbol:           "✔",
+			FailureSymbol:           "✖",
+		},
+		"ascii-ci": {
+			Variant: "ASCII",
+			Palette: map[int]map[color.Level]int{
+				Char:     monochrome(),
+				Message:  monochrome(),
+				Progress: monochrome(),
+			},
+			ProgressFormat: "[%s]",
+			Ellipsis:       "...",
+			SuccessSymbol:  "[ok]",
+			FailureSymbol:  "[fail]",
+		},
+	}
+)
+
+// Theme applies registered theme, see Themes() for names. Theme should be the first option,
+// the following options override theme settings
+//
+//	s, err := spinner.New(spinner.Theme("npm"), spinner.Prefix(">"))
+func Theme(name string) Option {
+	return func(s *Spinner) error {
+		themesLock.RLock()
+		t, ok := themes[name]
+		themesLock.RUnlock()
+		if !ok {
+			return fmt.Errorf("spinner: unknown theme: %v", name)
+		}
+		for _, option := range t.options() {
+			if err := option(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// RegisterTheme registers theme t under name, registered themes can't be replaced
+func RegisterTheme(name string, t ThemeSpec) error {
+	if name == "" {
+		return fmt.Errorf("spinner: theme name is empty")
+	}
+	// copy to keep registered palette untouched
+	p := make(map[int]map[color.Level]int, len(t.Palette))
+	for el, sets := range t.Palette {
+		p[el] = make(map[color.Level]int, len(sets))
+		for l, c := range sets {
+			p[el][l] = c
+		}
+	}
+	t.Palette = p
+	if _, err := New(t.options()...); err != nil {
+		return err
+	}
+	themesLock.Lock()
+	defer themesLock.Unlock()
+	if _, ok := themes[name]; ok {
+		return fmt.Errorf("spinner: theme is already registered: %v", name)
+	}
+	themes[name] = t
+	return nil
+}
+
+// Themes returns sorted names of registered themes
+func Themes() []string {
+	themesLock.RLock()
+	defer themesLock.RUnlock()
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// options returns options applying theme
+func (t ThemeSpec) options() []Option {
+	var options []Option
+	if t.Variant != "" {
+		options = append(options, func(s *Spinner) error {
+			v, ok := VariantByName(t.Variant)
+			if !ok {
+				return fmt.Errorf("spinner: unknown variant: %v", t.Variant)
+			}
+			return Variant(v)(s)
+		})
+	}
+	elements := make([]int, 0, len(t.Palette))
+	for el := range t.Palette {
+		elements = append(elements, el)
+	}
+	sort.Ints(elements)
+	for _, el := range elements {
+		options = append(options, ElementPalette(el, t.Palette[el]))
+	}
+	if t.CharFormat != "" {
+		options = append(options, Format(t.CharFormat))
+	}
+	if t.MessageFormat != "" {
+		options = append(options, MessageFormat(t.MessageFormat))
+	}
+	if t.ProgressFormat != "" {
+		options = append(options, ProgressFormat(t.ProgressFormat))
+	}
+	if t.ProgressIndicatorFormat != "" {
+		options = append(options, ProgressIndicatorFormat(t.ProgressIndicatorFormat))
+	}
+	if t.Ellipsis != "" {
+		options = append(options, MessageEllipsis(t.Ellipsis))
+	}
+	if t.SuccessSymbol != "" {
+		options = append(options, SuccessSymbol(t.SuccessSymbol))
+	}
+	if t.FailureSymbol != "" {
+		options = append(options, FailureSymbol(t.FailureSymbol))
+	}
+	return options
+}
@@ -0,0 +1,121 @@
+package spinner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alecrabbit/go-cli-spinner/color"
+)
+
+func TestThemes(t *testing.T) {
+	for _, name := range []string{"minimal", "npm", "dots-rainbow", "ascii-ci"} {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(Theme(name))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			themesLock.RLock()
+			spec := themes[name]
+			themesLock.RUnlock()
+			v, _ := VariantByName(spec.Variant)
+			if !reflect.DeepEqual(s.charSettings.charSet, CharSets[v].chars) {
+				t.Errorf("char set = %v, want %v", s.charSettings.charSet, CharSets[v].chars)
+			}
+			if s.successSymbol != spec.SuccessSymbol || s.failureSymbol != spec.FailureSymbol {
+				t.Errorf("symbols = %v %v, want %v %v",
+					s.successSymbol, s.failureSymbol, spec.SuccessSymbol, spec.FailureSymbol)
+			}
+			if s.messageEllipsis != spec.Ellipsis {
+				t.Errorf("ellipsis = %v, want %v", s.messageEllipsis, spec.Ellipsis)
+			}
+			for el, sets := range spec.Palette {
+				if got := s.elementsSettings[el].colorizingSet; got != sets[color.TColor256] {
+					t.Errorf("element %v colorizing set = %v, want %v", el, got, sets[color.TColor256])
+				}
+			}
+		})
+	}
+}
+
+func TestThemeOverride(t *testing.T) {
+	s, err := New(Theme("ascii-ci"), SuccessSymbol("OK"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.successSymbol != "OK" || s.failureSymbol != "[fail]" {
+		t.Errorf("symbols = %v %v, want OK [fail]", s.successSymbol, s.failureSymbol)
+	}
+}
+
+func TestThemeDefaultVariant(t *testing.T) {
+	if err := RegisterTheme("test-default-variant", ThemeSpec{SuccessSymbol: "√"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		themesLock.Lock()
+		delete(themes, "test-default-variant")
+		themesLock.Unlock()
+	}()
+	s, err := New(Theme("test-default-variant"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.charSettings.charSet, d.charSettings.charSet) {
+		t.Errorf("char set = %v, want default %v", s.charSettings.charSet, d.charSettings.charSet)
+	}
+}
+
+func TestRegisterTheme(t *testing.T) {
+	palette := map[int]map[color.Level]int{
+		Char: {color.TColor256: color.C256Rainbow, color.TColor16: color.CLightCyan},
+	}
+	tests := []struct {
+		name    string
+		theme   string
+		spec    ThemeSpec
+		wantErr bool
+	}{
+		{"ok", "test-corporate", ThemeSpec{Variant: "Snake", Palette: palette, SuccessSymbol: "√"}, false},
+		{"duplicate", "npm", ThemeSpec{Variant: "Snake"}, true},
+		{"empty name", "", ThemeSpec{Variant: "Snake"}, true},
+		{"unknown variant", "test-variant", ThemeSpec{Variant: "Snake99"}, true},
+		{"unknown element", "test-element", ThemeSpec{Palette: map[int]map[color.Level]int{10: {}}}, true},
+		{"unknown set", "test-set", ThemeSpec{Palette: map[int]map[color.Level]int{Char: {color.TColor16: -1}}}, true},
+		{"long ellipsis", "test-ellipsis", ThemeSpec{Ellipsis: "....."}, true},
+	}
+	defer func() {
+		themesLock.Lock()
+		delete(themes, "test-corporate")
+		themesLock.Unlock()
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterTheme(tt.theme, tt.spec); (err != nil) != tt.wantErr {
+				t.Errorf("RegisterTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	// registered palette is a copy
+	palette[Char][color.TColor256] = color.CDark
+	s, err := New(Theme("test-corporate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.charSettings.colorizingSet != color.C256Rainbow || s.successSymbol != "√" {
+		t.Errorf("registered theme is not applied")
+	}
+	if _, err := New(Theme("unknown")); err == nil {
+		t.Errorf("New() error = nil, want error for unknown theme")
+	}
+	found := false
+	for _, name := range Themes() {
+		found = found || name == "test-corporate"
+	}
+	if !found {
+		t.Errorf("Themes() = %v, registered theme is missing", Themes())
+	}
+}